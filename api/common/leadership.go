@@ -19,6 +19,22 @@ type LeadershipPinningAPI struct {
 	facade base.FacadeCaller
 }
 
+// PinApplicationsResult holds the outcome of a leadership pin/unpin call
+// that operates on a number of applications.
+type PinApplicationsResult struct {
+	// Errors has an entry for each application operated on, holding the
+	// error from its individual operation, or nil if it succeeded.
+	Errors map[names.ApplicationTag]error
+
+	// Info has an entry for each application for which the operation
+	// succeeded without needing to do anything, describing why.
+	Info map[names.ApplicationTag]string
+
+	// Message describes a call that succeeded without operating on any
+	// applications, such as for a machine with no units.
+	Message string
+}
+
 // NewLeadershipPinningAPI creates and returns a new leadership API client.
 func NewLeadershipPinningAPI(caller base.APICaller) *LeadershipPinningAPI {
 	facadeCaller := base.NewFacadeCaller(
@@ -43,7 +59,7 @@ func NewLeadershipPinningAPIFromFacade(facade base.FacadeCaller) *LeadershipPinn
 // machine, with the result of each individual pin operation.
 func (a *LeadershipPinningAPI) PinMachineApplications() (map[names.ApplicationTag]error, error) {
 	res, err := a.pinMachineAppsOps("PinMachineApplications")
-	return res, errors.Trace(err)
+	return res.Errors, errors.Trace(err)
 }
 
 // UnpinMachineApplications pins leadership for applications represented by
@@ -53,20 +69,261 @@ func (a *LeadershipPinningAPI) PinMachineApplications() (map[names.ApplicationTa
 // machine, with the result of each individual unpin operation.
 func (a *LeadershipPinningAPI) UnpinMachineApplications() (map[names.ApplicationTag]error, error) {
 	res, err := a.pinMachineAppsOps("UnpinMachineApplications")
+	return res.Errors, errors.Trace(err)
+}
+
+// ClearMachinePins removes all leadership pins held by the local machine.
+// If the caller is not a machine agent, an error will be returned.
+// The return has the result of each individual unpin operation,
+// for the applications for which pins were held.
+func (a *LeadershipPinningAPI) ClearMachinePins() (PinApplicationsResult, error) {
+	res, err := a.pinMachineAppsOps("ClearMachinePins")
 	return res, errors.Trace(err)
 }
 
+// PinnedLeadership returns the applications for which leadership is pinned,
+// along with the entities holding the pins.
+// Model admins see all pins; machine agents see only their own.
+func (a *LeadershipPinningAPI) PinnedLeadership() (map[string][]names.Tag, error) {
+	var callResult params.PinnedLeadershipResult
+	if err := a.facade.FacadeCall("PinnedLeadership", nil, &callResult); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if callResult.Error != nil {
+		return nil, errors.Trace(callResult.Error)
+	}
+
+	result := make(map[string][]names.Tag, len(callResult.Result))
+	for app, entities := range callResult.Result {
+		for _, entity := range entities {
+			tag, err := names.ParseTag(entity)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[app] = append(result[app], tag)
+		}
+	}
+	return result, nil
+}
+
+// PinCounts returns the number of entities pinning leadership for each
+// application with pinned leadership.
+// If the caller is not a model admin, an error will be returned.
+func (a *LeadershipPinningAPI) PinCounts() (map[names.ApplicationTag]int, error) {
+	var callResult params.PinCountsResult
+	if err := a.facade.FacadeCall("PinCounts", nil, &callResult); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[names.ApplicationTag]int, len(callResult.Counts))
+	for app, count := range callResult.Counts {
+		tag, err := names.ParseApplicationTag(app)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[tag] = count
+	}
+	return result, nil
+}
+
+// IsApplicationPinned returns the tags of all entities pinning leadership
+// for the input application. No tags are returned if it is not pinned.
+func (a *LeadershipPinningAPI) IsApplicationPinned(appName string) ([]names.Tag, error) {
+	arg := params.Entity{Tag: names.NewApplicationTag(appName).String()}
+	var callResult params.ApplicationPinnedResult
+	if err := a.facade.FacadeCall("IsApplicationPinned", arg, &callResult); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if callResult.Error != nil {
+		return nil, errors.Trace(callResult.Error)
+	}
+
+	var result []names.Tag
+	for _, entity := range callResult.EntityTags {
+		tag, err := names.ParseTag(entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+// PinApplication pins leadership for the input application on behalf of the
+// local user, recording the input reason against the pin if it is not empty.
+// If the caller is not a model admin, an error will be returned.
+func (a *LeadershipPinningAPI) PinApplication(appName, reason string) error {
+	arg := params.PinApplicationParams{
+		Tag:    names.NewApplicationTag(appName).String(),
+		Reason: reason,
+	}
+	var callResult params.PinApplicationResult
+	if err := a.facade.FacadeCall("PinApplication", arg, &callResult); err != nil {
+		return errors.Trace(err)
+	}
+	if callResult.Error != nil {
+		return errors.Trace(callResult.Error)
+	}
+	return nil
+}
+
+// UnpinApplication unpins leadership for the input application on behalf of
+// the local user.
+// If the caller is not a model admin, an error will be returned.
+func (a *LeadershipPinningAPI) UnpinApplication(appName string) error {
+	arg := params.Entity{Tag: names.NewApplicationTag(appName).String()}
+	var callResult params.PinApplicationResult
+	if err := a.facade.FacadeCall("UnpinApplication", arg, &callResult); err != nil {
+		return errors.Trace(err)
+	}
+	if callResult.Error != nil {
+		return errors.Trace(callResult.Error)
+	}
+	return nil
+}
+
+// PinApplications pins leadership for each of the input applications on
+// behalf of the local user.
+// If the caller is not a model admin, an error will be returned.
+// The return has the result of each individual pin operation.
+func (a *LeadershipPinningAPI) PinApplications(appNames []string) (PinApplicationsResult, error) {
+	res, err := a.pinAppsOps("PinApplications", appNames)
+	return res, errors.Trace(err)
+}
+
+// UnpinApplications unpins leadership for each of the input applications on
+// behalf of the local user.
+// If the caller is not a model admin, an error will be returned.
+// The return has the result of each individual unpin operation.
+func (a *LeadershipPinningAPI) UnpinApplications(appNames []string) (PinApplicationsResult, error) {
+	res, err := a.pinAppsOps("UnpinApplications", appNames)
+	return res, errors.Trace(err)
+}
+
+// PinAllApplications pins leadership for every application in the model on
+// behalf of the local user.
+// If the caller is not a model admin, an error will be returned.
+// The return has the result of each individual pin operation.
+func (a *LeadershipPinningAPI) PinAllApplications() (PinApplicationsResult, error) {
+	res, err := a.pinAppsCall("PinAllApplications", nil)
+	return res, errors.Trace(err)
+}
+
+// RePinApplications transfers all leadership pins held by the source entity
+// to the target entity.
+// If the caller is not a model admin, an error will be returned.
+// The return has the result of transferring each individual pin.
+func (a *LeadershipPinningAPI) RePinApplications(source, target names.Tag) (PinApplicationsResult, error) {
+	arg := params.RePinApplicationsParams{
+		SourceTag: source.String(),
+		TargetTag: target.String(),
+	}
+	res, err := a.pinAppsCall("RePinApplications", arg)
+	return res, errors.Trace(err)
+}
+
+// PinMachinesApplications pins leadership for applications represented by
+// units running on each of the input machines, on behalf of those machines.
+// If the caller is not a model admin, an error will be returned.
+// The return has, for each machine, the result of each individual pin
+// operation. Machines for which the applications could not be determined
+// are reported in the second return, keyed on machine tag.
+func (a *LeadershipPinningAPI) PinMachinesApplications(
+	machines []names.MachineTag,
+) (map[names.MachineTag]PinApplicationsResult, map[names.MachineTag]error, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(machines))}
+	for i, machine := range machines {
+		args.Entities[i].Tag = machine.String()
+	}
+	var callResult params.PinMachinesApplicationsResults
+	if err := a.facade.FacadeCall("PinMachinesApplications", args, &callResult); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	results := make(map[names.MachineTag]PinApplicationsResult, len(callResult.Results))
+	machineErrs := make(map[names.MachineTag]error)
+	for _, res := range callResult.Results {
+		tag, err := names.ParseMachineTag(res.MachineTag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if res.Error != nil {
+			machineErrs[tag] = res.Error
+			continue
+		}
+		appResults, err := pinAppsResults(res.Results, res.Info)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		results[tag] = appResults
+	}
+	return results, machineErrs, nil
+}
+
+// PinAndReportLeader pins leadership for the input application on behalf of
+// the local user, returning the unit that was leader when it was pinned.
+// If no unit was leader, false is returned.
+// If the caller is not a model admin, an error will be returned.
+func (a *LeadershipPinningAPI) PinAndReportLeader(appName string) (names.UnitTag, bool, error) {
+	arg := params.Entity{Tag: names.NewApplicationTag(appName).String()}
+	var callResult params.PinApplicationLeaderResult
+	if err := a.facade.FacadeCall("PinAndReportLeader", arg, &callResult); err != nil {
+		return names.UnitTag{}, false, errors.Trace(err)
+	}
+	if callResult.Pin.Error != nil {
+		return names.UnitTag{}, false, errors.Trace(callResult.Pin.Error)
+	}
+	if callResult.Error != nil {
+		return names.UnitTag{}, false, errors.Trace(callResult.Error)
+	}
+	if !callResult.LeaderElected {
+		return names.UnitTag{}, false, nil
+	}
+	leader, err := names.ParseUnitTag(callResult.LeaderTag)
+	if err != nil {
+		return names.UnitTag{}, false, errors.Trace(err)
+	}
+	return leader, true, nil
+}
+
 // pinMachineAppsOps makes a facade call to the input method name and
-// transforms the response into map.
-func (a *LeadershipPinningAPI) pinMachineAppsOps(callName string) (map[names.ApplicationTag]error, error) {
+// transforms the response into a PinApplicationsResult.
+func (a *LeadershipPinningAPI) pinMachineAppsOps(callName string) (PinApplicationsResult, error) {
+	res, err := a.pinAppsCall(callName, nil)
+	return res, errors.Trace(err)
+}
+
+// pinAppsOps makes a facade call to the input method name with the
+// tags for the input application names, and transforms the response
+// into a PinApplicationsResult.
+func (a *LeadershipPinningAPI) pinAppsOps(callName string, appNames []string) (PinApplicationsResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(appNames))}
+	for i, appName := range appNames {
+		args.Entities[i].Tag = names.NewApplicationTag(appName).String()
+	}
+	res, err := a.pinAppsCall(callName, args)
+	return res, errors.Trace(err)
+}
+
+// pinAppsCall makes a facade call to the input method name with the
+// input arguments, and transforms the response into a PinApplicationsResult.
+func (a *LeadershipPinningAPI) pinAppsCall(callName string, args interface{}) (PinApplicationsResult, error) {
 	var callResult params.PinApplicationsResults
-	err := a.facade.FacadeCall(callName, nil, &callResult)
+	err := a.facade.FacadeCall(callName, args, &callResult)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return PinApplicationsResult{}, errors.Trace(err)
 	}
+	res, err := pinAppsResults(callResult.Results, callResult.Info)
+	return res, errors.Trace(err)
+}
 
-	callResults := callResult.Results
-	result := make(map[names.ApplicationTag]error, len(callResults))
+// pinAppsResults transforms the input pin/unpin results and call
+// information into a PinApplicationsResult.
+func pinAppsResults(callResults []params.PinApplicationResult, info string) (PinApplicationsResult, error) {
+	result := PinApplicationsResult{
+		Errors:  make(map[names.ApplicationTag]error, len(callResults)),
+		Message: info,
+	}
 	for _, res := range callResults {
 		var appErr error
 		if res.Error != nil {
@@ -74,9 +331,15 @@ func (a *LeadershipPinningAPI) pinMachineAppsOps(callName string) (map[names.App
 		}
 		tag, err := names.ParseApplicationTag(res.ApplicationTag)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return PinApplicationsResult{}, errors.Trace(err)
+		}
+		result.Errors[tag] = appErr
+		if res.Info != "" {
+			if result.Info == nil {
+				result.Info = make(map[names.ApplicationTag]string)
+			}
+			result.Info[tag] = res.Info
 		}
-		result[tag] = appErr
 	}
 	return result, nil
 }
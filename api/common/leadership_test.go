@@ -72,6 +72,241 @@ func (s *LeadershipSuite) TestUnpinMachineApplicationsSuccess(c *gc.C) {
 	c.Check(res, gc.DeepEquals, s.pinApplicationsClientSuccessResults())
 }
 
+func (s *LeadershipSuite) TestClearMachinePins(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("ClearMachinePins", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.ClearMachinePins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{Errors: s.pinApplicationsClientSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestClearMachinePinsInfo(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	results := s.pinApplicationsServerSuccessResults()
+	results[0].Info = `leadership not pinned by "machine-0"`
+	resultSource := params.PinApplicationsResults{Results: results}
+	s.facade.EXPECT().FacadeCall("ClearMachinePins", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.ClearMachinePins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{
+		Errors: s.pinApplicationsClientSuccessResults(),
+		Info: map[names.ApplicationTag]string{
+			names.NewApplicationTag("mysql"): `leadership not pinned by "machine-0"`,
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinAllApplicationsNoApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	resultSource := params.PinApplicationsResults{Info: "no applications in model"}
+	s.facade.EXPECT().FacadeCall("PinAllApplications", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinAllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{
+		Errors:  map[names.ApplicationTag]error{},
+		Message: "no applications in model",
+	})
+}
+
+func (s *LeadershipSuite) TestPinnedLeadership(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	resultSource := params.PinnedLeadershipResult{
+		Result: map[string][]string{
+			"mysql": {names.NewMachineTag("0").String(), names.NewUserTag("admin").String()},
+		},
+	}
+	s.facade.EXPECT().FacadeCall("PinnedLeadership", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, map[string][]names.Tag{
+		"mysql": {names.NewMachineTag("0"), names.NewUserTag("admin")},
+	})
+}
+
+func (s *LeadershipSuite) TestPinCounts(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	resultSource := params.PinCountsResult{
+		Counts: map[string]int{names.NewApplicationTag("mysql").String(): 2},
+	}
+	s.facade.EXPECT().FacadeCall("PinCounts", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinCounts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, map[names.ApplicationTag]int{names.NewApplicationTag("mysql"): 2})
+}
+
+func (s *LeadershipSuite) TestIsApplicationPinned(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.Entity{Tag: names.NewApplicationTag("mysql").String()}
+	resultSource := params.ApplicationPinnedResult{
+		Pinned:     true,
+		EntityTags: []string{names.NewMachineTag("0").String()},
+	}
+	s.facade.EXPECT().FacadeCall("IsApplicationPinned", arg, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.IsApplicationPinned("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, []names.Tag{names.NewMachineTag("0")})
+}
+
+func (s *LeadershipSuite) TestPinApplication(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.PinApplicationParams{Tag: names.NewApplicationTag("mysql").String(), Reason: "maintenance"}
+	s.facade.EXPECT().FacadeCall("PinApplication", arg, gomock.Any()).SetArg(2, params.PinApplicationResult{})
+
+	err := s.client.PinApplication("mysql", "maintenance")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipSuite) TestPinApplicationError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.PinApplicationParams{Tag: names.NewApplicationTag("mysql").String()}
+	resultSource := params.PinApplicationResult{Error: apiservercommon.ServerError(apiservercommon.ErrPerm)}
+	s.facade.EXPECT().FacadeCall("PinApplication", arg, gomock.Any()).SetArg(2, resultSource)
+
+	err := s.client.PinApplication("mysql", "")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestUnpinApplication(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.Entity{Tag: names.NewApplicationTag("mysql").String()}
+	s.facade.EXPECT().FacadeCall("UnpinApplication", arg, gomock.Any()).SetArg(2, params.PinApplicationResult{})
+
+	err := s.client.UnpinApplication("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipSuite) TestPinApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	args := params.Entities{Entities: make([]params.Entity, len(s.machineApps))}
+	for i, app := range s.machineApps {
+		args.Entities[i].Tag = names.NewApplicationTag(app).String()
+	}
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("PinApplications", args, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinApplications(s.machineApps)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{Errors: s.pinApplicationsClientSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestUnpinApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	args := params.Entities{Entities: make([]params.Entity, len(s.machineApps))}
+	for i, app := range s.machineApps {
+		args.Entities[i].Tag = names.NewApplicationTag(app).String()
+	}
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("UnpinApplications", args, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.UnpinApplications(s.machineApps)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{Errors: s.pinApplicationsClientSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestPinAllApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("PinAllApplications", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinAllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{Errors: s.pinApplicationsClientSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestRePinApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.RePinApplicationsParams{
+		SourceTag: names.NewMachineTag("0").String(),
+		TargetTag: names.NewMachineTag("1").String(),
+	}
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("RePinApplications", arg, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.RePinApplications(names.NewMachineTag("0"), names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, common.PinApplicationsResult{Errors: s.pinApplicationsClientSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestPinMachinesApplications(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewMachineTag("0").String()},
+		{Tag: names.NewMachineTag("1").String()},
+		{Tag: names.NewMachineTag("2").String()},
+	}}
+	notFound := apiservercommon.ServerError(errors.New("boom"))
+	resultSource := params.PinMachinesApplicationsResults{Results: []params.PinMachineApplicationsResult{
+		{MachineTag: names.NewMachineTag("0").String(), Results: s.pinApplicationsServerSuccessResults()},
+		{MachineTag: names.NewMachineTag("1").String(), Error: notFound},
+		{MachineTag: names.NewMachineTag("2").String(), Info: `no applications on machine "2"`},
+	}}
+	s.facade.EXPECT().FacadeCall("PinMachinesApplications", args, gomock.Any()).SetArg(2, resultSource)
+
+	res, machineErrs, err := s.client.PinMachinesApplications(
+		[]names.MachineTag{names.NewMachineTag("0"), names.NewMachineTag("1"), names.NewMachineTag("2")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, map[names.MachineTag]common.PinApplicationsResult{
+		names.NewMachineTag("0"): {Errors: s.pinApplicationsClientSuccessResults()},
+		names.NewMachineTag("2"): {
+			Errors:  map[names.ApplicationTag]error{},
+			Message: `no applications on machine "2"`,
+		},
+	})
+	c.Check(machineErrs, gc.DeepEquals, map[names.MachineTag]error{names.NewMachineTag("1"): notFound})
+}
+
+func (s *LeadershipSuite) TestPinAndReportLeader(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.Entity{Tag: names.NewApplicationTag("mysql").String()}
+	resultSource := params.PinApplicationLeaderResult{
+		Pin:           params.PinApplicationResult{ApplicationTag: names.NewApplicationTag("mysql").String()},
+		LeaderElected: true,
+		LeaderTag:     names.NewUnitTag("mysql/1").String(),
+	}
+	s.facade.EXPECT().FacadeCall("PinAndReportLeader", arg, gomock.Any()).SetArg(2, resultSource)
+
+	leader, elected, err := s.client.PinAndReportLeader("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(elected, jc.IsTrue)
+	c.Check(leader, gc.Equals, names.NewUnitTag("mysql/1"))
+}
+
+func (s *LeadershipSuite) TestPinAndReportLeaderNoLeader(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	arg := params.Entity{Tag: names.NewApplicationTag("mysql").String()}
+	resultSource := params.PinApplicationLeaderResult{
+		Pin: params.PinApplicationResult{ApplicationTag: names.NewApplicationTag("mysql").String()},
+	}
+	s.facade.EXPECT().FacadeCall("PinAndReportLeader", arg, gomock.Any()).SetArg(2, resultSource)
+
+	_, elected, err := s.client.PinAndReportLeader("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(elected, jc.IsFalse)
+}
+
 func (s *LeadershipSuite) setup(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
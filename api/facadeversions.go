@@ -55,6 +55,7 @@ var facadeVersions = map[string]int{
 	"InstancePoller":               3,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
+	"LeadershipPinning":            1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
@@ -102,7 +103,7 @@ var facadeVersions = map[string]int{
 	"UnitAssigner":                 1,
 	"Uniter":                       8,
 	"Upgrader":                     1,
-	"UpgradeSeries":                1,
+	"UpgradeSeries":                2,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 	"VolumeAttachmentPlansWatcher": 1,
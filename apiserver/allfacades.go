@@ -198,6 +198,7 @@ func AllFacades() *facade.Registry {
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
+	reg("LeadershipPinning", 1, common.NewLeadershipPinningFacade)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
 
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
@@ -281,7 +282,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 8, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
-	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
+	reg("UpgradeSeries", 1, upgradeseries.NewAPIv1)
+	reg("UpgradeSeries", 2, upgradeseries.NewAPI) // Adds leadership pin queries and staggered/TTL pins.
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
 
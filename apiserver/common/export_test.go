@@ -3,9 +3,36 @@
 
 package common
 
+import (
+	"github.com/juju/clock"
+)
+
 var (
 	MachineJobFromParams    = machineJobFromParams
 	EnvtoolsFindTools       = &envtoolsFindTools
 	SendMetrics             = &sendMetrics
 	MockableDestroyMachines = destroyMachines
+	ErrAlreadyPinned        = errAlreadyPinned
+	ErrNotPinned            = errNotPinned
+	ErrAborted              = errAborted
 )
+
+// SetLeadershipPinAudit replaces the audit function
+// of the input leadership pinning API.
+func SetLeadershipPinAudit(api LeadershipPinningAPI, audit func(LeadershipPinAuditEntry)) {
+	api.(*leadershipPinningAPI).audit = audit
+}
+
+// SetLeadershipPinClock replaces the clock
+// of the input leadership pinning API.
+func SetLeadershipPinClock(api LeadershipPinningAPI, clock clock.Clock) {
+	api.(*leadershipPinningAPI).clock = clock
+}
+
+// EntityRateLimiterSize returns the number of
+// buckets held by the input rate limiter.
+func EntityRateLimiterSize(l *EntityRateLimiter) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
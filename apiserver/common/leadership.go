@@ -4,17 +4,70 @@
 package common
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 //go:generate mockgen -package mocks -destination mocks/leadership.go github.com/juju/juju/apiserver/common LeadershipPinningBackend,LeadershipMachine
 
+// MaxPinTTL is the longest duration for which leadership may be pinned
+// with automatic release.
+const MaxPinTTL = 24 * time.Hour
+
+// MaxUnpinStagger is the longest total duration over which unpinning
+// of a machine's applications may be spread.
+const MaxUnpinStagger = 2 * time.Minute
+
+// MaxPinWait is the longest duration for which a caller may wait
+// for requested leadership pins to be confirmed.
+const MaxPinWait = 5 * time.Minute
+
+// LeadershipPinRateLimit configures the rate at which a single
+// authenticated entity may pin and unpin application leadership.
+type LeadershipPinRateLimit struct {
+	// Burst is the number of pin/unpin operations allowed
+	// before rate limiting is applied.
+	Burst int64
+
+	// Refill is the interval at which further operations
+	// are allowed once the burst has been used.
+	Refill time.Duration
+}
+
+// DefaultLeadershipPinRateLimit is the rate limit applied to pin/unpin
+// operations for each authenticated entity. It is generous enough
+// never to affect normal usage.
+var DefaultLeadershipPinRateLimit = LeadershipPinRateLimit{
+	Burst:  1000,
+	Refill: 10 * time.Millisecond,
+}
+
+// errAlreadyPinned is returned by pin operations when leadership for an
+// application is already pinned by the requesting entity.
+var errAlreadyPinned = errors.New("already pinned")
+
+// errNotPinned is returned by unpin operations when leadership for an
+// application is not pinned by the requesting entity.
+var errNotPinned = errors.New("not pinned")
+
+// errAborted is returned by long-running operations that are abandoned
+// because the API connection is closing.
+var errAborted = errors.New("aborted: API connection closing")
+
 // LeadershipMachine is an indirection for state.machine.
 type LeadershipMachine interface {
 	ApplicationNames() ([]string, error)
@@ -27,6 +80,8 @@ type leadershipMachine struct {
 // LeadershipPinningBacked describes state method wrappers used by this API.
 type LeadershipPinningBackend interface {
 	Machine(string) (LeadershipMachine, error)
+	ApplicationLeaders() (map[string]string, error)
+	AllApplicationNames() ([]string, error)
 }
 
 type leadershipPinningBackend struct {
@@ -43,15 +98,63 @@ func (s leadershipPinningBackend) Machine(name string) (LeadershipMachine, error
 	return leadershipMachine{m}, nil
 }
 
+// AllApplicationNames returns the names of all applications in the model.
+func (s leadershipPinningBackend) AllApplicationNames() ([]string, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, err
+	}
+	appNames := make([]string, len(apps))
+	for i, app := range apps {
+		appNames[i] = app.Name()
+	}
+	return appNames, nil
+}
+
 // API exposes leadership pinning and unpinning functionality for remote use.
+//
+// Errors returned by its methods, and errors in individual results, are
+// reported to clients with the following codes:
+//   - params.CodeUnauthorized if the caller may not perform the operation;
+//   - params.CodeNotFound if the auth'd machine, or an application on it,
+//     does not exist;
+//   - params.CodeBadRequest if an argument, such as a tag or duration,
+//     is invalid;
+//   - params.CodeTryAgain if the caller has exceeded its pin rate limit.
 type LeadershipPinningAPI interface {
+	PinnedLeadership() (params.PinnedLeadershipResult, error)
+	PinCounts() (params.PinCountsResult, error)
+	IsApplicationPinned(params.Entity) (params.ApplicationPinnedResult, error)
+	WatchLeadershipPins() (params.NotifyWatchResult, error)
 	PinMachineApplications() (params.PinApplicationsResults, error)
+	PinMachineApplicationsAndWait(params.PinLeadershipWaitParams) (params.ConfirmedPinResults, error)
 	UnpinMachineApplications() (params.PinApplicationsResults, error)
+	UnpinMachineApplicationsWithDelay(params.UnpinLeadershipStaggerParams) (params.StaggeredUnpinResults, error)
+	ClearMachinePins() (params.PinApplicationsResults, error)
+	RePinApplications(params.RePinApplicationsParams) (params.PinApplicationsResults, error)
+	PinMachineApplicationsWithTTL(params.PinLeadershipTTLParams) (params.PinApplicationsResults, error)
+	PinApplicationOnMachine(params.Entity) (params.PinApplicationResult, error)
+	PinMachinesApplications(params.Entities) (params.PinMachinesApplicationsResults, error)
+	PinApplication(params.PinApplicationParams) (params.PinApplicationResult, error)
+	PinAndReportLeader(params.Entity) (params.PinApplicationLeaderResult, error)
+	PinApplicationByName(params.ApplicationNameParams) (params.PinApplicationResult, error)
+	PinAllApplications() (params.PinApplicationsResults, error)
+	UnpinApplicationByName(params.ApplicationNameParams) (params.PinApplicationResult, error)
+	UnpinApplication(params.Entity) (params.PinApplicationResult, error)
+	PinApplications(params.Entities) (params.PinApplicationsResults, error)
+	UnpinApplications(params.Entities) (params.PinApplicationsResults, error)
 }
 
 // NewLeadershipPinningFacade creates and returns a new leadership API.
 // This signature is suitable for facade registration.
+// The API may be used by clients and machine agents; individual methods
+// further restrict access to model admins or machine agents.
 func NewLeadershipPinningFacade(ctx facade.Context) (LeadershipPinningAPI, error) {
+	authorizer := ctx.Auth()
+	if !authorizer.AuthClient() && !authorizer.AuthMachineAgent() {
+		return nil, ErrPerm
+	}
+
 	st := ctx.State()
 	model, err := st.Model()
 	if err != nil {
@@ -61,71 +164,929 @@ func NewLeadershipPinningFacade(ctx facade.Context) (LeadershipPinningAPI, error
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewLeadershipPinningAPI(leadershipPinningBackend{st}, model.ModelTag(), pinner, ctx.Auth())
+	return NewLeadershipPinningAPI(
+		leadershipPinningBackend{st},
+		model.ModelTag(),
+		pinner,
+		ctx.Resources(),
+		authorizer,
+		ctx.LeadershipPinRateLimiter(),
+		ctx.LeadershipPinMachineCache(),
+	)
 }
 
 // NewLeadershipPinningAPI creates and returns a new leadership API from the
-// input tag, Pinner implementation and facade Authorizer.
+// input tag, Pinner implementation, facade Resources, facade Authorizer,
+// pin/unpin rate limiter and machine application names cache.
+// A nil limiter applies no limit, and a nil cache disables caching.
 func NewLeadershipPinningAPI(
-	st LeadershipPinningBackend, modelTag names.ModelTag, pinner leadership.Pinner, authorizer facade.Authorizer,
+	st LeadershipPinningBackend,
+	modelTag names.ModelTag,
+	pinner leadership.Pinner,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	limiter facade.RateLimiter,
+	machineCache facade.MachineApplicationsCache,
 ) (LeadershipPinningAPI, error) {
 	return &leadershipPinningAPI{
-		st:         st,
-		modelTag:   modelTag,
-		pinner:     pinner,
-		authorizer: authorizer,
+		st:           st,
+		modelTag:     modelTag,
+		pinner:       pinner,
+		resources:    resources,
+		authorizer:   authorizer,
+		audit:        logLeadershipPinAudit,
+		clock:        clock.WallClock,
+		limiter:      limiter,
+		machineCache: machineCache,
 	}, nil
 }
 
 type leadershipPinningAPI struct {
-	st         LeadershipPinningBackend
-	modelTag   names.ModelTag
-	pinner     leadership.Pinner
-	authorizer facade.Authorizer
+	st           LeadershipPinningBackend
+	modelTag     names.ModelTag
+	pinner       leadership.Pinner
+	resources    facade.Resources
+	authorizer   facade.Authorizer
+	audit        func(LeadershipPinAuditEntry)
+	clock        clock.Clock
+	limiter      facade.RateLimiter
+	machineCache facade.MachineApplicationsCache
+}
+
+// LeadershipPinAuditEntry describes a single attempt
+// to pin or unpin leadership for an application.
+type LeadershipPinAuditEntry struct {
+	// AuthTag is the tag of the authenticated caller.
+	AuthTag names.Tag
+	// Entity is the tag of the entity on whose behalf the pin is held.
+	Entity names.Tag
+	// Application is the name of the application.
+	Application string
+	// Operation describes the pin or unpin operation attempted.
+	Operation string
+	// Error is the result of the operation, if it failed.
+	Error error
+}
+
+// logLeadershipPinAudit is the default audit function for the leadership
+// pinning API. It writes each audit entry to the package logger.
+func logLeadershipPinAudit(entry LeadershipPinAuditEntry) {
+	result := "succeeded"
+	switch {
+	case entry.Error == errAlreadyPinned:
+		result = "not needed: already pinned"
+	case entry.Error == errNotPinned:
+		result = "not needed: not pinned"
+	case entry.Error != nil:
+		result = fmt.Sprintf("failed: %v", entry.Error)
+	}
+	logger.Infof("leadership %s of %q for %s requested by %s %s",
+		entry.Operation, entry.Application, entry.Entity, entry.AuthTag, result)
+}
+
+// PinnedLeadership returns all pinned applications and the entities that
+// require their pinned behaviour, for leadership in the current model.
+// Model admins see all pins; machine agents see only their own.
+func (a *leadershipPinningAPI) PinnedLeadership() (params.PinnedLeadershipResult, error) {
+	result := params.PinnedLeadershipResult{}
+
+	isAdmin, err := a.authModelAdmin()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isAdmin && !a.authorizer.AuthMachineAgent() {
+		return result, ErrPerm
+	}
+	tag := a.authorizer.GetAuthTag()
+	include := func(_ string, entity names.Tag) bool {
+		return isAdmin || entity == tag
+	}
+
+	result.Result = a.pinnedLeadership(include)
+	result.Reasons = a.pinReasons(include)
+	return result, nil
+}
+
+// PinCounts returns the number of entities pinning leadership for each
+// application with pinned leadership in the current model, keyed on
+// application tag. Only model admins may call this method.
+func (a *leadershipPinningAPI) PinCounts() (params.PinCountsResult, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinCountsResult{}, err
+	}
+
+	counts := make(map[string]int)
+	for app, entities := range a.pinner.PinnedLeadership() {
+		counts[names.NewApplicationTag(app).String()] = len(entities)
+	}
+	return params.PinCountsResult{Counts: counts}, nil
+}
+
+// IsApplicationPinned returns whether leadership for the input application is
+// currently pinned, along with the tags of all entities pinning it.
+// Model admins may query any application; machine agents may query only
+// applications represented by units running on the auth'd machine.
+func (a *leadershipPinningAPI) IsApplicationPinned(arg params.Entity) (params.ApplicationPinnedResult, error) {
+	result := params.ApplicationPinnedResult{}
+
+	isAdmin, err := a.authModelAdmin()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isAdmin && !a.authorizer.AuthMachineAgent() {
+		return result, ErrPerm
+	}
+	appTag, err := names.ParseApplicationTag(arg.Tag)
+	if err != nil {
+		return result, badRequest(err)
+	}
+
+	if !isAdmin {
+		tag, err := a.authMachineTag()
+		if err != nil {
+			return result, err
+		}
+		apps, err := a.machineApplicationNames(tag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if !set.NewStrings(apps...).Contains(appTag.Id()) {
+			return result, ErrPerm
+		}
+	}
+
+	for _, entity := range a.pinner.PinnedLeadership()[appTag.Id()] {
+		result.EntityTags = append(result.EntityTags, entity.String())
+	}
+	result.Pinned = len(result.EntityTags) > 0
+	return result, nil
+}
+
+// pinReasons returns the reasons recorded for current leadership pins as
+// strings, keyed on application name and pinning entity.
+// Only pins for which the input filter returns true are included.
+// Nil is returned if there are no such reasons.
+func (a *leadershipPinningAPI) pinReasons(include func(string, names.Tag) bool) map[string]map[string]string {
+	var reasons map[string]map[string]string
+	for app, entities := range a.pinner.LeadershipPinReasons() {
+		for entity, reason := range entities {
+			if !include(app, entity) {
+				continue
+			}
+			if reasons == nil {
+				reasons = make(map[string]map[string]string)
+			}
+			if reasons[app] == nil {
+				reasons[app] = make(map[string]string)
+			}
+			reasons[app][entity.String()] = reason
+		}
+	}
+	return reasons
+}
+
+// pinnedLeadership returns the current leadership pins as strings,
+// keyed on application name.
+// Only pins for which the input filter returns true are included.
+func (a *leadershipPinningAPI) pinnedLeadership(include func(string, names.Tag) bool) map[string][]string {
+	pinned := make(map[string][]string)
+	for app, entities := range a.pinner.PinnedLeadership() {
+		for _, entity := range entities {
+			if include(app, entity) {
+				pinned[app] = append(pinned[app], entity.String())
+			}
+		}
+	}
+	return pinned
+}
+
+// WatchLeadershipPins returns a watcher that notifies of changes to pinned
+// leadership in the current model.
+// Model admins are notified of changes to all pins. Machine agents are
+// notified of changes to pins for applications represented by units running
+// on the auth'd machine. The machine's applications are read again each time
+// pins are polled, so applications deployed to it later are included.
+func (a *leadershipPinningAPI) WatchLeadershipPins() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+
+	isAdmin, err := a.authModelAdmin()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var pinned func() (map[string][]string, error)
+	switch {
+	case isAdmin:
+		pinned = func() (map[string][]string, error) {
+			return a.pinnedLeadership(func(string, names.Tag) bool { return true }), nil
+		}
+	case a.authorizer.AuthMachineAgent():
+		tag, err := a.authMachineTag()
+		if err != nil {
+			return result, err
+		}
+		pinned = func() (map[string][]string, error) {
+			apps, err := a.machineApplicationNames(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machineApps := set.NewStrings(apps...)
+			return a.pinnedLeadership(func(app string, _ names.Tag) bool { return machineApps.Contains(app) }), nil
+		}
+	default:
+		return result, ErrPerm
+	}
+
+	w := NewPinnedLeadershipWatcher(pinned, a.clock, PinnedLeadershipPollInterval)
+	if _, ok := <-w.Changes(); ok {
+		result.NotifyWatcherId = a.resources.Register(w)
+		return result, nil
+	}
+	return result, watcher.EnsureErr(w)
 }
 
 // PinMachineApplications pins leadership for applications represented by units
 // running on the auth'd machine.
 func (a *leadershipPinningAPI) PinMachineApplications() (params.PinApplicationsResults, error) {
-	if !a.authorizer.AuthMachineAgent() {
-		return params.PinApplicationsResults{}, ErrPerm
-	}
-	return a.pinMachineAppsOps(a.pinner.PinLeadership)
+	return a.pinMachineAppsOps(a.pinLeadershipOp(""))
 }
 
 // UnpinMachineApplications unpins leadership for applications represented by
 // units running on the auth'd machine.
 func (a *leadershipPinningAPI) UnpinMachineApplications() (params.PinApplicationsResults, error) {
-	if !a.authorizer.AuthMachineAgent() {
-		return params.PinApplicationsResults{}, ErrPerm
+	return a.pinMachineAppsOps(a.unpinLeadershipOp())
+}
+
+// UnpinMachineApplicationsWithDelay unpins leadership for applications
+// represented by units running on the auth'd machine, waiting for the input
+// stagger between each unpin so that re-elections are spread out.
+// The total stagger may not exceed MaxUnpinStagger.
+// Each result records the offset from the start of the call at which
+// its unpin was performed.
+// If the API connection is closed while waiting, the remaining
+// applications are left pinned and an error is returned.
+func (a *leadershipPinningAPI) UnpinMachineApplicationsWithDelay(
+	arg params.UnpinLeadershipStaggerParams,
+) (params.StaggeredUnpinResults, error) {
+	tag, err := a.authMachineTag()
+	if err != nil {
+		return params.StaggeredUnpinResults{}, err
+	}
+	stagger := time.Duration(arg.StaggerSeconds * float64(time.Second))
+	if stagger < 0 {
+		return params.StaggeredUnpinResults{}, errors.BadRequestf("invalid unpin stagger %v (must not be negative)", stagger)
+	}
+	apps, err := a.cachedMachineApplicationNames(tag)
+	if err != nil {
+		return params.StaggeredUnpinResults{}, errors.Trace(err)
+	}
+	if len(apps) > 1 && stagger*time.Duration(len(apps)-1) > MaxUnpinStagger {
+		return params.StaggeredUnpinResults{}, errors.BadRequestf(
+			"invalid unpin stagger %v for %d applications (total must be at most %v)", stagger, len(apps), MaxUnpinStagger)
 	}
-	return a.pinMachineAppsOps(a.pinner.UnpinLeadership)
+
+	abort, release := a.abortChannel()
+	defer release()
+
+	op := a.unpinLeadershipOp()
+	start := a.clock.Now()
+	results := make([]params.StaggeredUnpinResult, len(apps))
+	for i, app := range apps {
+		if i > 0 && stagger > 0 {
+			select {
+			case <-abort:
+				return params.StaggeredUnpinResults{}, errAborted
+			case <-a.clock.After(stagger):
+			}
+		}
+		results[i] = params.StaggeredUnpinResult{
+			Result:        a.pinApplication(names.NewApplicationTag(app), tag, op),
+			OffsetSeconds: a.clock.Now().Sub(start).Seconds(),
+		}
+	}
+	return params.StaggeredUnpinResults{Results: results}, nil
 }
 
-// pinMachineAppsOps runs the input pin/unpin operation against all
-// applications represented by units on the authorised machine.
-// An assumption is made that the validity of the auth tag has been verified
-// by the caller.
-func (a *leadershipPinningAPI) pinMachineAppsOps(op func(string, names.Tag) error) (params.PinApplicationsResults, error) {
-	tag := a.authorizer.GetAuthTag()
+// ClearMachinePins removes all leadership pins held by the auth'd machine,
+// including those for applications no longer represented by units on it.
+// It is intended to be called by a machine agent during teardown.
+// The results include only applications for which a pin was held,
+// so calling it when no pins remain returns no results.
+func (a *leadershipPinningAPI) ClearMachinePins() (params.PinApplicationsResults, error) {
+	tag, err := a.authMachineTag()
+	if err != nil {
+		return params.PinApplicationsResults{}, err
+	}
 
-	m, err := a.st.Machine(tag.Id())
+	var apps []string
+	for app := range a.pinnedLeadership(func(_ string, entity names.Tag) bool {
+		return entity == tag
+	}) {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	op := a.unpinLeadershipOp()
+	results := make([]params.PinApplicationResult, len(apps))
+	for i, app := range apps {
+		results[i] = a.pinApplication(names.NewApplicationTag(app), tag, op)
+	}
+	return params.PinApplicationsResults{Results: results}, nil
+}
+
+// RePinApplications transfers all leadership pins held by the source entity
+// to the target entity. For each application, the target's pin is taken
+// before the source's pin is released, so that leadership remains pinned
+// throughout. The target's pins keep the reasons and remaining durations
+// of the source's. Re-running the operation after partial completion only
+// transfers the pins that the source still holds.
+// The source and target must be different entities.
+// The auth'd user must be a model admin.
+func (a *leadershipPinningAPI) RePinApplications(arg params.RePinApplicationsParams) (params.PinApplicationsResults, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinApplicationsResults{}, err
+	}
+	source, err := names.ParseTag(arg.SourceTag)
 	if err != nil {
-		return params.PinApplicationsResults{}, errors.Trace(err)
+		return params.PinApplicationsResults{}, badRequest(err)
 	}
-	apps, err := m.ApplicationNames()
+	target, err := names.ParseTag(arg.TargetTag)
 	if err != nil {
-		return params.PinApplicationsResults{}, errors.Trace(err)
+		return params.PinApplicationsResults{}, badRequest(err)
+	}
+	if source == target {
+		// Pinning for the target would be a no-op, and the subsequent
+		// unpin would release the source's pins rather than move them.
+		return params.PinApplicationsResults{}, errors.BadRequestf("cannot re-pin applications from %q to itself", source)
+	}
+
+	var apps []string
+	for app := range a.pinnedLeadership(func(_ string, entity names.Tag) bool {
+		return entity == source
+	}) {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	// The target's pins are taken with the same reason and expiry as the
+	// source's, so that a TTL pin is not made permanent by the transfer.
+	reasons := a.pinner.LeadershipPinReasons()
+	expiries := a.pinner.LeadershipPinExpiries()
+
+	pin := a.pinLeadershipOp("")
+	pinWithTTL := a.audited("pin with TTL", a.rateLimited(func(appName string, entity names.Tag) error {
+		return a.pinner.PinLeadershipWithTTL(appName, entity, expiries[appName][source].Sub(a.clock.Now()))
+	}))
+	unpin := a.unpinLeadershipOp()
+	rePin := func(appName string, entity names.Tag) error {
+		var err error
+		if expiry, ok := expiries[appName][source]; ok {
+			// A source pin that has already expired is not transferred.
+			if a.clock.Now().Before(expiry) {
+				err = pinWithTTL(appName, entity)
+			}
+		} else if reason := reasons[appName][source]; reason != "" {
+			err = a.pinLeadershipOp(reason)(appName, entity)
+		} else {
+			err = pin(appName, entity)
+		}
+		if err != nil && err != errAlreadyPinned {
+			return errors.Trace(err)
+		}
+		if err := unpin(appName, source); err != nil && err != errNotPinned {
+			return errors.Trace(err)
+		}
+		return nil
 	}
 
 	results := make([]params.PinApplicationResult, len(apps))
 	for i, app := range apps {
-		results[i] = params.PinApplicationResult{
-			ApplicationTag: names.NewApplicationTag(app).String(),
+		results[i] = a.pinApplication(names.NewApplicationTag(app), target, rePin)
+	}
+	return params.PinApplicationsResults{Results: results}, nil
+}
+
+// PinMachineApplicationsAndWait pins leadership for applications represented
+// by units running on the auth'd machine, then waits for up to the input
+// timeout, which may not exceed MaxPinWait, until the leadership core reports
+// each successful pin as being in effect.
+// Each such result has Confirmed set if the pin was seen before the timeout.
+// If the API connection is closed while waiting, an error is returned.
+func (a *leadershipPinningAPI) PinMachineApplicationsAndWait(
+	arg params.PinLeadershipWaitParams,
+) (params.ConfirmedPinResults, error) {
+	tag, err := a.authMachineTag()
+	if err != nil {
+		return params.ConfirmedPinResults{}, err
+	}
+	timeout := time.Duration(arg.TimeoutSeconds * float64(time.Second))
+	if timeout <= 0 || timeout > MaxPinWait {
+		return params.ConfirmedPinResults{}, errors.BadRequestf("invalid pin wait timeout %v (must be positive and at most %v)", timeout, MaxPinWait)
+	}
+
+	pinResults, err := a.machineAppsOps(tag, a.pinLeadershipOp(""))
+	if err != nil {
+		return params.ConfirmedPinResults{}, errors.Trace(err)
+	}
+	results := make([]params.ConfirmedPinResult, len(pinResults.Results))
+	for i, res := range pinResults.Results {
+		results[i].Result = res
+	}
+	if err := a.confirmPins(results, tag, timeout); err != nil {
+		return params.ConfirmedPinResults{}, errors.Trace(err)
+	}
+	return params.ConfirmedPinResults{Results: results, Info: pinResults.Info}, nil
+}
+
+// confirmPins polls the Pinner until pins for the input entity are reported
+// for each of the input results without an error, or until the timeout
+// elapses. Results for which the pin was seen have Confirmed set.
+// errAborted is returned if the API connection is closed while waiting.
+func (a *leadershipPinningAPI) confirmPins(results []params.ConfirmedPinResult, entity names.Tag, timeout time.Duration) error {
+	abort, release := a.abortChannel()
+	defer release()
+
+	deadline := a.clock.Now().Add(timeout)
+	for {
+		pending := false
+		pinned := a.pinnedLeadership(func(_ string, e names.Tag) bool { return e == entity })
+		for i, res := range results {
+			if res.Result.Error != nil || res.Confirmed {
+				continue
+			}
+			appTag, _ := names.ParseApplicationTag(res.Result.ApplicationTag)
+			if _, ok := pinned[appTag.Id()]; ok {
+				results[i].Confirmed = true
+			} else {
+				pending = true
+			}
+		}
+
+		remaining := deadline.Sub(a.clock.Now())
+		if !pending || remaining <= 0 {
+			return nil
+		}
+		if remaining > PinnedLeadershipPollInterval {
+			remaining = PinnedLeadershipPollInterval
+		}
+		select {
+		case <-abort:
+			return errAborted
+		case <-a.clock.After(remaining):
+		}
+	}
+}
+
+// PinMachineApplicationsWithTTL pins leadership for applications represented
+// by units running on the auth'd machine. The pins are released automatically
+// once the input duration, which may not exceed MaxPinTTL, has elapsed.
+func (a *leadershipPinningAPI) PinMachineApplicationsWithTTL(
+	arg params.PinLeadershipTTLParams,
+) (params.PinApplicationsResults, error) {
+	if _, err := a.authMachineTag(); err != nil {
+		return params.PinApplicationsResults{}, err
+	}
+	ttl := time.Duration(arg.DurationSeconds * float64(time.Second))
+	if ttl <= 0 || ttl > MaxPinTTL {
+		return params.PinApplicationsResults{}, errors.BadRequestf("invalid pin TTL %v (must be positive and at most %v)", ttl, MaxPinTTL)
+	}
+	return a.pinMachineAppsOps(a.audited("pin with TTL", a.rateLimited(func(appName string, entity names.Tag) error {
+		return a.pinner.PinLeadershipWithTTL(appName, entity, ttl)
+	})))
+}
+
+// PinApplicationOnMachine pins leadership for the input application, which
+// must be represented by a unit running on the auth'd machine.
+func (a *leadershipPinningAPI) PinApplicationOnMachine(arg params.Entity) (params.PinApplicationResult, error) {
+	tag, err := a.authMachineTag()
+	if err != nil {
+		return params.PinApplicationResult{}, err
+	}
+	appTag, err := names.ParseApplicationTag(arg.Tag)
+	if err != nil {
+		return params.PinApplicationResult{}, badRequest(err)
+	}
+
+	apps, err := a.machineApplicationNames(tag)
+	if err != nil {
+		return params.PinApplicationResult{}, errors.Trace(err)
+	}
+	if !set.NewStrings(apps...).Contains(appTag.Id()) {
+		return params.PinApplicationResult{}, errors.NotFoundf("application %q on machine %q", appTag.Id(), tag.Id())
+	}
+
+	return a.pinApplication(appTag, tag, a.pinLeadershipOp("")), nil
+}
+
+// PinMachinesApplications pins leadership for applications represented by
+// units running on each of the input machines. Pins are held on behalf of
+// each machine, as if it had called PinMachineApplications itself.
+// The auth'd user must be a model admin.
+func (a *leadershipPinningAPI) PinMachinesApplications(args params.Entities) (params.PinMachinesApplicationsResults, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinMachinesApplicationsResults{}, err
+	}
+
+	op := a.pinLeadershipOp("")
+	results := make([]params.PinMachineApplicationsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		results[i].MachineTag = arg.Tag
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results[i].Error = ServerError(badRequest(err))
+			continue
 		}
-		if err := op(app, tag); err != nil {
+		res, err := a.machineAppsOps(tag, op)
+		if err != nil {
 			results[i].Error = ServerError(err)
+			continue
+		}
+		results[i].Results = res.Results
+		results[i].Info = res.Info
+	}
+	return params.PinMachinesApplicationsResults{Results: results}, nil
+}
+
+// PinApplication pins leadership for the input application on behalf of
+// the auth'd user, who must be a model admin.
+// If a reason is supplied, it is recorded against the pin.
+func (a *leadershipPinningAPI) PinApplication(arg params.PinApplicationParams) (params.PinApplicationResult, error) {
+	return a.pinAppOp(params.Entity{Tag: arg.Tag}, a.pinLeadershipOp(arg.Reason))
+}
+
+// PinAllApplications pins leadership for every application in the model on
+// behalf of the auth'd user, who must be a model admin.
+// Applications already pinned by the user are reported with Info set,
+// so the operation may be safely re-run after partial failure.
+func (a *leadershipPinningAPI) PinAllApplications() (params.PinApplicationsResults, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinApplicationsResults{}, err
+	}
+	apps, err := a.st.AllApplicationNames()
+	if err != nil {
+		return params.PinApplicationsResults{}, errors.Trace(err)
+	}
+	sort.Strings(apps)
+
+	tag := a.authorizer.GetAuthTag()
+	op := a.pinLeadershipOp("")
+	results := make([]params.PinApplicationResult, len(apps))
+	for i, app := range apps {
+		results[i] = a.pinApplication(names.NewApplicationTag(app), tag, op)
+	}
+	return params.PinApplicationsResults{Results: results}, nil
+}
+
+// PinApplicationByName pins leadership for the application with the input
+// name on behalf of the auth'd user, who must be a model admin.
+func (a *leadershipPinningAPI) PinApplicationByName(arg params.ApplicationNameParams) (params.PinApplicationResult, error) {
+	return a.pinAppNameOp(arg, a.pinLeadershipOp(""))
+}
+
+// UnpinApplicationByName unpins leadership for the application with the input
+// name on behalf of the auth'd user, who must be a model admin.
+func (a *leadershipPinningAPI) UnpinApplicationByName(arg params.ApplicationNameParams) (params.PinApplicationResult, error) {
+	return a.pinAppNameOp(arg, a.unpinLeadershipOp())
+}
+
+// pinAppNameOp resolves the tag for the input application name,
+// then runs the input pin/unpin operation against it.
+func (a *leadershipPinningAPI) pinAppNameOp(
+	arg params.ApplicationNameParams, op func(string, names.Tag) error,
+) (params.PinApplicationResult, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinApplicationResult{}, err
+	}
+	if !names.IsValidApplication(arg.ApplicationName) {
+		return params.PinApplicationResult{}, errors.BadRequestf("invalid application name %q", arg.ApplicationName)
+	}
+	return a.pinAppOp(params.Entity{Tag: names.NewApplicationTag(arg.ApplicationName).String()}, op)
+}
+
+// PinAndReportLeader pins leadership for the input application on behalf of
+// the auth'd user, who must be a model admin. If the pin succeeds, the unit
+// currently holding leadership for the application is also returned.
+// If no unit is currently the leader, LeaderElected is false.
+func (a *leadershipPinningAPI) PinAndReportLeader(arg params.Entity) (params.PinApplicationLeaderResult, error) {
+	pinResult, err := a.pinAppOp(arg, a.pinLeadershipOp(""))
+	if err != nil {
+		return params.PinApplicationLeaderResult{}, err
+	}
+	result := params.PinApplicationLeaderResult{Pin: pinResult}
+	if pinResult.Error != nil {
+		return result, nil
+	}
+
+	leaders, err := a.st.ApplicationLeaders()
+	if err != nil {
+		result.Error = ServerError(err)
+		return result, nil
+	}
+	appTag, _ := names.ParseApplicationTag(pinResult.ApplicationTag)
+	if leader, ok := leaders[appTag.Id()]; ok {
+		result.LeaderElected = true
+		result.LeaderTag = names.NewUnitTag(leader).String()
+	}
+	return result, nil
+}
+
+// UnpinApplication unpins leadership for the input application on behalf of
+// the auth'd user, who must be a model admin.
+func (a *leadershipPinningAPI) UnpinApplication(arg params.Entity) (params.PinApplicationResult, error) {
+	return a.pinAppOp(arg, a.unpinLeadershipOp())
+}
+
+// PinApplications pins leadership for each of the input applications on
+// behalf of the auth'd user, who must be a model admin.
+func (a *leadershipPinningAPI) PinApplications(args params.Entities) (params.PinApplicationsResults, error) {
+	return a.pinAppsOps(args, a.pinLeadershipOp(""))
+}
+
+// UnpinApplications unpins leadership for each of the input applications on
+// behalf of the auth'd user, who must be a model admin.
+func (a *leadershipPinningAPI) UnpinApplications(args params.Entities) (params.PinApplicationsResults, error) {
+	return a.pinAppsOps(args, a.unpinLeadershipOp())
+}
+
+// pinAppOp runs the input pin/unpin operation against the application
+// with the input tag, recording the auth'd model admin as the pin entity.
+func (a *leadershipPinningAPI) pinAppOp(
+	arg params.Entity, op func(string, names.Tag) error,
+) (params.PinApplicationResult, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinApplicationResult{}, errors.Trace(err)
+	}
+	appTag, err := names.ParseApplicationTag(arg.Tag)
+	if err != nil {
+		return params.PinApplicationResult{}, badRequest(err)
+	}
+	return a.pinApplication(appTag, a.authorizer.GetAuthTag(), op), nil
+}
+
+// pinAppsOps runs the input pin/unpin operation against each of the
+// applications with the input tags, recording the auth'd model admin as the
+// pin entity. Invalid application tags are reported in the individual results.
+func (a *leadershipPinningAPI) pinAppsOps(
+	args params.Entities, op func(string, names.Tag) error,
+) (params.PinApplicationsResults, error) {
+	if err := a.checkModelAdmin(); err != nil {
+		return params.PinApplicationsResults{}, errors.Trace(err)
+	}
+
+	tag := a.authorizer.GetAuthTag()
+	results := make([]params.PinApplicationResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		appTag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results[i] = params.PinApplicationResult{
+				ApplicationTag: entity.Tag,
+				EntityTag:      tag.String(),
+				Error:          ServerError(badRequest(err)),
+			}
+			continue
+		}
+		results[i] = a.pinApplication(appTag, tag, op)
+	}
+	return params.PinApplicationsResults{Results: results}, nil
+}
+
+// pinApplication runs the input pin/unpin operation for the input
+// application and entity, returning the result.
+// The result records the auth'd entity as having requested the operation,
+// which differs from the input entity when pinning on behalf of another.
+func (a *leadershipPinningAPI) pinApplication(
+	appTag names.ApplicationTag, entity names.Tag, op func(string, names.Tag) error,
+) params.PinApplicationResult {
+	result := params.PinApplicationResult{
+		ApplicationTag: appTag.String(),
+		EntityTag:      a.authorizer.GetAuthTag().String(),
+	}
+	if err := op(appTag.Id(), entity); err == errAlreadyPinned {
+		result.Info = fmt.Sprintf("leadership already pinned by %q", entity.String())
+	} else if err == errNotPinned {
+		result.Info = fmt.Sprintf("leadership not pinned by %q", entity.String())
+	} else if err != nil {
+		result.Error = ServerError(err)
+	}
+	return result
+}
+
+// pinLeadershipOp returns a pin operation that forwards to the Pinner only
+// for applications not already pinned by the input entity.
+// Those that are already pinned result in errAlreadyPinned, so that idempotent
+// pinning is not reported as a failure. A pin made with a TTL does not count;
+// forwarding replaces it with a pin that is held until unpinned.
+// Current pins are read once, when the operation is first run.
+// If the input reason is not empty, the operation always forwards to the
+// Pinner so that the reason is recorded, even for an existing pin.
+func (a *leadershipPinningAPI) pinLeadershipOp(reason string) func(string, names.Tag) error {
+	if reason != "" {
+		return a.audited("pin with reason", a.rateLimited(func(appName string, entity names.Tag) error {
+			return a.pinner.PinLeadershipWithReason(appName, entity, reason)
+		}))
+	}
+
+	var (
+		pinned   map[string][]names.Tag
+		expiries map[string]map[names.Tag]time.Time
+	)
+	read := false
+	return a.audited("pin", a.rateLimited(func(appName string, entity names.Tag) error {
+		if !read {
+			pinned = a.pinner.PinnedLeadership()
+			expiries = a.pinner.LeadershipPinExpiries()
+			read = true
 		}
+		if _, ttl := expiries[appName][entity]; !ttl {
+			for _, e := range pinned[appName] {
+				if e == entity {
+					return errAlreadyPinned
+				}
+			}
+		}
+		return a.pinner.PinLeadership(appName, entity)
+	}))
+}
+
+// badRequest wraps the input argument validation error so that it is
+// reported to clients with params.CodeBadRequest.
+func badRequest(err error) error {
+	return errors.NewBadRequest(err, "")
+}
+
+// unpinLeadershipOp returns an unpin operation that forwards to the Pinner
+// only for applications pinned by the input entity.
+// Those that are not result in errNotPinned, so that unpinning is idempotent
+// and is not reported as a failure when only other entities hold pins.
+// Current pins are read once, when the operation is first run.
+func (a *leadershipPinningAPI) unpinLeadershipOp() func(string, names.Tag) error {
+	var pinned map[string][]names.Tag
+	read := false
+	return a.audited("unpin", a.rateLimited(func(appName string, entity names.Tag) error {
+		if !read {
+			pinned = a.pinner.PinnedLeadership()
+			read = true
+		}
+		for _, e := range pinned[appName] {
+			if e == entity {
+				return a.pinner.UnpinLeadership(appName, entity)
+			}
+		}
+		return errNotPinned
+	}))
+}
+
+// audited wraps the input pin/unpin operation so that each attempt,
+// successful or not, is recorded by the API's audit function.
+func (a *leadershipPinningAPI) audited(operation string, op func(string, names.Tag) error) func(string, names.Tag) error {
+	return func(appName string, entity names.Tag) error {
+		err := op(appName, entity)
+		a.audit(LeadershipPinAuditEntry{
+			AuthTag:     a.authorizer.GetAuthTag(),
+			Entity:      entity,
+			Application: appName,
+			Operation:   operation,
+			Error:       err,
+		})
+		return err
+	}
+}
+
+// rateLimited wraps the input pin/unpin operation so that attempts
+// exceeding the rate limit for the auth'd entity in the current model
+// are not forwarded, and fail with ErrTryAgain.
+func (a *leadershipPinningAPI) rateLimited(op func(string, names.Tag) error) func(string, names.Tag) error {
+	if a.limiter == nil {
+		return op
+	}
+	return func(appName string, entity names.Tag) error {
+		if !a.limiter.Allow(a.modelTag.Id(), a.authorizer.GetAuthTag()) {
+			return ErrTryAgain
+		}
+		return op(appName, entity)
+	}
+}
+
+// abortOnStop is a facade.Resource with a channel
+// that is closed when the resource is stopped.
+type abortOnStop struct {
+	once  sync.Once
+	abort chan struct{}
+}
+
+// Stop (facade.Resource) closes the abort channel.
+func (r *abortOnStop) Stop() error {
+	r.once.Do(func() { close(r.abort) })
+	return nil
+}
+
+// abortChannel returns a channel that is closed if the API's resources are
+// stopped, as they are when the API connection is closed, so that long-running
+// operations can be abandoned. The returned function must be called to
+// release the channel once the operation is complete.
+func (a *leadershipPinningAPI) abortChannel() (<-chan struct{}, func()) {
+	r := &abortOnStop{abort: make(chan struct{})}
+	id := a.resources.Register(r)
+	return r.abort, func() { a.resources.Stop(id) }
+}
+
+// pinMachineAppsOps runs the input pin/unpin operation against all
+// applications represented by units on the authorised machine.
+// ErrPerm is returned if the authenticated entity is not a machine agent.
+func (a *leadershipPinningAPI) pinMachineAppsOps(op func(string, names.Tag) error) (params.PinApplicationsResults, error) {
+	tag, err := a.authMachineTag()
+	if err != nil {
+		return params.PinApplicationsResults{}, err
+	}
+	return a.machineAppsOps(tag, op)
+}
+
+// machineAppsOps runs the input pin/unpin operation on behalf of the input
+// machine, against all applications represented by units on it.
+// If there are no such applications, the result has no entries and
+// Info indicates that there was nothing to do.
+func (a *leadershipPinningAPI) machineAppsOps(
+	tag names.MachineTag, op func(string, names.Tag) error,
+) (params.PinApplicationsResults, error) {
+	apps, err := a.cachedMachineApplicationNames(tag)
+	if err != nil {
+		return params.PinApplicationsResults{}, errors.Trace(err)
+	}
+	if len(apps) == 0 {
+		return params.PinApplicationsResults{
+			Results: []params.PinApplicationResult{},
+			Info:    fmt.Sprintf("no applications on machine %q", tag.Id()),
+		}, nil
+	}
+
+	results := make([]params.PinApplicationResult, len(apps))
+	for i, app := range apps {
+		results[i] = a.pinApplication(names.NewApplicationTag(app), tag, op)
 	}
 	return params.PinApplicationsResults{Results: results}, nil
 }
+
+// authMachineTag returns the tag of the authenticated machine agent.
+// ErrPerm is returned if the authenticated entity is not a machine agent
+// or its tag is not a machine tag.
+func (a *leadershipPinningAPI) authMachineTag() (names.MachineTag, error) {
+	if !a.authorizer.AuthMachineAgent() {
+		return names.MachineTag{}, ErrPerm
+	}
+	tag, ok := a.authorizer.GetAuthTag().(names.MachineTag)
+	if !ok {
+		return names.MachineTag{}, ErrPerm
+	}
+	return tag, nil
+}
+
+// authModelAdmin returns true if the authenticated entity is a user with
+// admin access to the model.
+func (a *leadershipPinningAPI) authModelAdmin() (bool, error) {
+	if !a.authorizer.AuthClient() {
+		return false, nil
+	}
+	isAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, a.modelTag)
+	return isAdmin, errors.Trace(err)
+}
+
+// checkModelAdmin returns ErrPerm if the authenticated entity
+// is not a user with admin access to the model.
+func (a *leadershipPinningAPI) checkModelAdmin() error {
+	isAdmin, err := a.authModelAdmin()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return ErrPerm
+	}
+	return nil
+}
+
+// cachedMachineApplicationNames returns the names of applications represented
+// by units running on the machine with the input tag, as machineApplicationNames
+// does, but reusing names recently read for the machine if they are cached.
+// The names are used only to enumerate applications for pin and unpin
+// operations; authorization decisions must not rely on cached names.
+func (a *leadershipPinningAPI) cachedMachineApplicationNames(tag names.MachineTag) ([]string, error) {
+	if a.machineCache == nil {
+		return a.machineApplicationNames(tag)
+	}
+	return a.machineCache.ApplicationNames(a.modelTag.Id(), tag.Id(), func() ([]string, error) {
+		return a.machineApplicationNames(tag)
+	})
+}
+
+// machineApplicationNames returns the names of applications represented by
+// units running on the machine with the input tag, read from state.
+// If the machine does not exist, a not-found error is returned.
+func (a *leadershipPinningAPI) machineApplicationNames(tag names.MachineTag) ([]string, error) {
+	m, err := a.st.Machine(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("machine %q", tag.Id())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps, err := m.ApplicationNames()
+	return apps, errors.Trace(err)
+}
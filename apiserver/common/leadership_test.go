@@ -4,7 +4,10 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -13,10 +16,15 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	commonmocks "github.com/juju/juju/apiserver/common/mocks"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/leadership/mocks"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type LeadershipSuite struct {
@@ -26,26 +34,32 @@ type LeadershipSuite struct {
 	machine *commonmocks.MockLeadershipMachine
 	pinner  *mocks.MockPinner
 
-	tag         names.Tag
-	api         common.LeadershipPinningAPI
-	machineApps []string
+	resources *common.Resources
+
+	tag          names.Tag
+	modelTag     names.ModelTag
+	api          common.LeadershipPinningAPI
+	machineApps  []string
+	pinExpiries  map[string]map[names.Tag]time.Time
+	limiter      facade.RateLimiter
+	machineCache facade.MachineApplicationsCache
 }
 
 var _ = gc.Suite(&LeadershipSuite{})
 
-func (s *LeadershipSuite) SetUpSuite(c *gc.C) {
-	s.BaseSuite.SetUpSuite(c)
-	s.machineApps = []string{"mysql", "redis", "wordpress"}
-}
-
 func (s *LeadershipSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.tag = nil
+	s.machineApps = []string{"mysql", "redis", "wordpress"}
+	s.pinExpiries = nil
+	s.limiter = nil
+	s.machineCache = nil
 }
 
 func (s *LeadershipSuite) TestPinMachineApplicationsSuccess(c *gc.C) {
 	defer s.setup(c).Finish()
 
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
 	for _, app := range s.machineApps {
 		s.pinner.EXPECT().PinLeadership(app, s.tag).Return(nil)
 	}
@@ -59,6 +73,7 @@ func (s *LeadershipSuite) TestPinMachineApplicationsPartialError(c *gc.C) {
 	defer s.setup(c).Finish()
 
 	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
 	s.pinner.EXPECT().PinLeadership("mysql", s.tag).Return(nil)
 	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
 	s.pinner.EXPECT().PinLeadership("wordpress", s.tag).Return(errorRes)
@@ -71,9 +86,303 @@ func (s *LeadershipSuite) TestPinMachineApplicationsPartialError(c *gc.C) {
 	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
 }
 
+func (s *LeadershipSuite) TestPinMachineApplicationsAlreadyPinned(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql": {s.tag},
+		"redis": {names.NewMachineTag("1")},
+	})
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("wordpress", s.tag).Return(nil)
+
+	res, err := s.api.PinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.pinApplicationsSuccessResults()
+	results[0].Info = `leadership already pinned by "machine-0"`
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsSupersedesTTLPin(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	s.pinExpiries = map[string]map[names.Tag]time.Time{
+		"mysql": {tag: time.Now().Add(time.Minute)},
+	}
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql": {tag},
+		"redis": {tag},
+	})
+	s.pinner.EXPECT().PinLeadership("mysql", tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("wordpress", tag).Return(nil)
+
+	res, err := s.api.PinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.pinApplicationsSuccessResults()
+	results[1].Info = `leadership already pinned by "machine-0"`
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsWithTTLSuccess(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().PinLeadershipWithTTL(app, s.tag, 90*time.Second).Return(nil)
+	}
+
+	res, err := s.api.PinMachineApplicationsWithTTL(params.PinLeadershipTTLParams{DurationSeconds: 90})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: s.pinApplicationsSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsWithTTLInvalid(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	for _, seconds := range []float64{0, -1, (common.MaxPinTTL + time.Second).Seconds()} {
+		_, err := s.api.PinMachineApplicationsWithTTL(params.PinLeadershipTTLParams{DurationSeconds: seconds})
+		c.Check(err, jc.Satisfies, errors.IsBadRequest)
+	}
+}
+
+func (s *LeadershipSuite) TestPinAuditing(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	var entries []common.LeadershipPinAuditEntry
+	common.SetLeadershipPinAudit(s.api, func(entry common.LeadershipPinAuditEntry) {
+		entries = append(entries, entry)
+	})
+
+	errorRes := errors.New("boom")
+	gomock.InOrder(
+		s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag("wordpress")),
+		s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag("mysql", "wordpress")),
+	)
+	s.pinner.EXPECT().PinLeadership("mysql", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(errorRes)
+	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("wordpress", s.tag).Return(nil)
+
+	_, err := s.api.PinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.UnpinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	entry := func(app, op string, err error) common.LeadershipPinAuditEntry {
+		return common.LeadershipPinAuditEntry{
+			AuthTag:     s.tag,
+			Entity:      s.tag,
+			Application: app,
+			Operation:   op,
+			Error:       err,
+		}
+	}
+	c.Check(entries, jc.DeepEquals, []common.LeadershipPinAuditEntry{
+		entry("mysql", "pin", nil),
+		entry("redis", "pin", errorRes),
+		entry("wordpress", "pin", common.ErrAlreadyPinned),
+		entry("mysql", "unpin", nil),
+		entry("redis", "unpin", common.ErrNotPinned),
+		entry("wordpress", "unpin", nil),
+	})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsAndWaitConfirmed(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	gomock.InOrder(
+		s.pinner.EXPECT().PinnedLeadership().Return(nil),
+		s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+			"mysql":     {s.tag},
+			"redis":     {s.tag},
+			"wordpress": {s.tag},
+		}),
+	)
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().PinLeadership(app, s.tag).Return(nil)
+	}
+
+	res, err := s.api.PinMachineApplicationsAndWait(params.PinLeadershipWaitParams{TimeoutSeconds: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.confirmedPinResults()
+	for i := range results {
+		results[i].Confirmed = true
+	}
+	c.Check(res, gc.DeepEquals, params.ConfirmedPinResults{Results: results})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsAndWaitPending(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	common.SetLeadershipPinClock(s.api, clock)
+
+	errorRes := errors.New("boom")
+	gomock.InOrder(
+		s.pinner.EXPECT().PinnedLeadership().Return(nil),
+		s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{"mysql": {s.tag}}),
+		s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{"mysql": {s.tag}}),
+	)
+	s.pinner.EXPECT().PinLeadership("mysql", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("wordpress", s.tag).Return(errorRes)
+
+	done := make(chan params.ConfirmedPinResults)
+	go func() {
+		res, err := s.api.PinMachineApplicationsAndWait(params.PinLeadershipWaitParams{TimeoutSeconds: 1})
+		c.Check(err, jc.ErrorIsNil)
+		done <- res
+	}()
+
+	err := clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case res := <-done:
+		results := s.confirmedPinResults()
+		results[0].Confirmed = true
+		results[2].Result.Error = common.ServerError(errorRes)
+		c.Check(res, gc.DeepEquals, params.ConfirmedPinResults{Results: results})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for pin results")
+	}
+
+	// The abort channel is released once the call completes.
+	c.Check(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsAndWaitAborted(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	common.SetLeadershipPinClock(s.api, clock)
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil).Times(2)
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().PinLeadership(app, s.tag).Return(nil)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := s.api.PinMachineApplicationsAndWait(params.PinLeadershipWaitParams{TimeoutSeconds: 10})
+		done <- err
+	}()
+
+	// Closing the connection stops its resources, abandoning the wait.
+	err := clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.resources.StopAll()
+
+	select {
+	case err := <-done:
+		c.Check(errors.Cause(err), gc.Equals, common.ErrAborted)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for pin wait to be aborted")
+	}
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsAndWaitInvalid(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	for _, seconds := range []float64{0, -1, (common.MaxPinWait + time.Second).Seconds()} {
+		_, err := s.api.PinMachineApplicationsAndWait(params.PinLeadershipWaitParams{TimeoutSeconds: seconds})
+		c.Check(err, jc.Satisfies, errors.IsBadRequest)
+	}
+}
+
+func (s *LeadershipSuite) TestPinRateLimited(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	s.limiter = common.NewEntityRateLimiter(clock, 2, time.Second)
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...)).Times(2)
+	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil).Times(2)
+	s.pinner.EXPECT().UnpinLeadership("redis", s.tag).Return(nil)
+
+	res, err := s.api.UnpinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.pinApplicationsSuccessResults()
+	results[2].Error = common.ServerError(common.ErrTryAgain)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
+	c.Check(res.Results[2].Error, jc.Satisfies, params.IsCodeTryAgain)
+
+	// One further operation is allowed after the refill interval.
+	clock.Advance(time.Second)
+	res, err = s.api.UnpinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results = s.pinApplicationsSuccessResults()
+	results[1].Error = common.ServerError(common.ErrTryAgain)
+	results[2].Error = common.ServerError(common.ErrTryAgain)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
+}
+
+func (s *LeadershipSuite) TestPinRateLimitSharedBetweenAPIs(c *gc.C) {
+	s.limiter = common.NewEntityRateLimiter(testclock.NewClock(time.Now()), 1, time.Second)
+	defer s.setup(c).Finish()
+
+	// A second API, as created for another connection by the same entity,
+	// draws on the same allowance.
+	sameModel := s.newAPI(c, s.modelTag)
+
+	// An entity with the same tag in another model has its own allowance.
+	otherModel := s.newAPI(c, names.NewModelTag(utils.MustNewUUID().String()))
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil).Times(2)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("wordpress", s.tag).Return(nil)
+
+	res, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Error, gc.IsNil)
+
+	res, err = sameModel.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("mysql").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Error, jc.Satisfies, params.IsCodeTryAgain)
+
+	res, err = otherModel.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("wordpress").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Error, gc.IsNil)
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsUsesMachineCache(c *gc.C) {
+	s.machineCache = fixedMachineCache{"mysql"}
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("mysql", s.tag).Return(nil)
+
+	res, err := s.api.PinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: s.pinApplicationsSuccessResults()[:1]})
+}
+
+func (s *LeadershipSuite) TestPinApplicationOnMachineIgnoresMachineCache(c *gc.C) {
+	s.machineCache = fixedMachineCache{}
+	defer s.setup(c).Finish()
+
+	// Access is decided on the machine's current applications,
+	// never on those cached for enumeration.
+	s.pinner.EXPECT().PinnedLeadership().Return(nil).Times(2)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+
+	res, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Error, gc.IsNil)
+
+	isPinned, err := s.api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(isPinned.Pinned, jc.IsFalse)
+}
+
 func (s *LeadershipSuite) TestUnpinMachineApplicationsSuccess(c *gc.C) {
 	defer s.setup(c).Finish()
 
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...))
 	for _, app := range s.machineApps {
 		s.pinner.EXPECT().UnpinLeadership(app, s.tag).Return(nil)
 	}
@@ -83,10 +392,226 @@ func (s *LeadershipSuite) TestUnpinMachineApplicationsSuccess(c *gc.C) {
 	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: s.pinApplicationsSuccessResults()})
 }
 
+func (s *LeadershipSuite) TestUnpinMachineApplicationsWithDelay(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	common.SetLeadershipPinClock(s.api, clock)
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...))
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().UnpinLeadership(app, s.tag).Return(nil)
+	}
+
+	done := make(chan params.StaggeredUnpinResults)
+	go func() {
+		res, err := s.api.UnpinMachineApplicationsWithDelay(params.UnpinLeadershipStaggerParams{StaggerSeconds: 10})
+		c.Check(err, jc.ErrorIsNil)
+		done <- res
+	}()
+
+	for i := 0; i < len(s.machineApps)-1; i++ {
+		err := clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	select {
+	case res := <-done:
+		pinResults := s.pinApplicationsSuccessResults()
+		results := make([]params.StaggeredUnpinResult, len(pinResults))
+		for i, pinResult := range pinResults {
+			results[i] = params.StaggeredUnpinResult{Result: pinResult, OffsetSeconds: float64(10 * i)}
+		}
+		c.Check(res, gc.DeepEquals, params.StaggeredUnpinResults{Results: results})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for unpin results")
+	}
+
+	// The abort channel is released once the call completes.
+	c.Check(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *LeadershipSuite) TestUnpinMachineApplicationsWithDelayAborted(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	common.SetLeadershipPinClock(s.api, clock)
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...))
+	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil)
+
+	done := make(chan error)
+	go func() {
+		_, err := s.api.UnpinMachineApplicationsWithDelay(params.UnpinLeadershipStaggerParams{StaggerSeconds: 10})
+		done <- err
+	}()
+
+	// Closing the connection stops its resources,
+	// abandoning the remaining unpins.
+	err := clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.resources.StopAll()
+
+	select {
+	case err := <-done:
+		c.Check(err, gc.Equals, common.ErrAborted)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for unpin to be aborted")
+	}
+}
+
+func (s *LeadershipSuite) TestUnpinMachineApplicationsWithDelayTooLong(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	stagger := common.MaxUnpinStagger / 2
+	_, err := s.api.UnpinMachineApplicationsWithDelay(params.UnpinLeadershipStaggerParams{
+		StaggerSeconds: (stagger + time.Second).Seconds(),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (s *LeadershipSuite) TestClearMachinePins(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql":     {names.NewMachineTag("0"), names.NewMachineTag("1")},
+		"postgres":  {names.NewMachineTag("0")},
+		"wordpress": {names.NewMachineTag("1")},
+	}).Times(2)
+	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("postgres", s.tag).Return(nil)
+
+	res, err := s.api.ClearMachinePins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{
+		Results: []params.PinApplicationResult{{
+			ApplicationTag: names.NewApplicationTag("mysql").String(),
+			EntityTag:      s.tag.String(),
+		}, {
+			ApplicationTag: names.NewApplicationTag("postgres").String(),
+			EntityTag:      s.tag.String(),
+		}},
+	})
+}
+
+func (s *LeadershipSuite) TestClearMachinePinsNoPins(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"wordpress": {names.NewMachineTag("1")},
+	})
+
+	res, err := s.api.ClearMachinePins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Results, gc.HasLen, 0)
+}
+
+func (s *LeadershipSuite) TestRePinApplications(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	source := names.NewMachineTag("0")
+	target := names.NewMachineTag("1")
+	pinned := map[string][]names.Tag{
+		"mysql":     {source},
+		"redis":     {source, target},
+		"wordpress": {target},
+	}
+	s.pinner.EXPECT().PinnedLeadership().Return(pinned).Times(3)
+	s.pinner.EXPECT().LeadershipPinReasons().Return(nil)
+	s.pinner.EXPECT().PinLeadership("mysql", target).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("mysql", source).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("redis", source).Return(nil)
+
+	res, err := s.api.RePinApplications(params.RePinApplicationsParams{
+		SourceTag: source.String(),
+		TargetTag: target.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{
+		Results: []params.PinApplicationResult{{
+			ApplicationTag: names.NewApplicationTag("mysql").String(),
+			EntityTag:      s.tag.String(),
+		}, {
+			ApplicationTag: names.NewApplicationTag("redis").String(),
+			EntityTag:      s.tag.String(),
+		}},
+	})
+}
+
+func (s *LeadershipSuite) TestRePinApplicationsKeepsReasonAndTTL(c *gc.C) {
+	source := names.NewMachineTag("0")
+	target := names.NewMachineTag("1")
+	clock := testclock.NewClock(time.Now())
+	s.tag = names.NewUserTag("admin")
+	s.pinExpiries = map[string]map[names.Tag]time.Time{
+		"redis":     {source: clock.Now().Add(time.Minute)},
+		"wordpress": {source: clock.Now().Add(-time.Second)},
+	}
+	defer s.setup(c).Finish()
+	common.SetLeadershipPinClock(s.api, clock)
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql":     {source},
+		"redis":     {source},
+		"wordpress": {source},
+	}).Times(2)
+	s.pinner.EXPECT().LeadershipPinReasons().Return(map[string]map[names.Tag]string{
+		"mysql": {source: "series upgrade"},
+	})
+	s.pinner.EXPECT().PinLeadershipWithReason("mysql", target, "series upgrade").Return(nil)
+	s.pinner.EXPECT().PinLeadershipWithTTL("redis", target, time.Minute).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("mysql", source).Return(nil)
+	s.pinner.EXPECT().UnpinLeadership("redis", source).Return(nil)
+	// The expired wordpress pin is released but not transferred.
+	s.pinner.EXPECT().UnpinLeadership("wordpress", source).Return(nil)
+
+	res, err := s.api.RePinApplications(params.RePinApplicationsParams{
+		SourceTag: source.String(),
+		TargetTag: target.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	for _, result := range res.Results {
+		c.Check(result.Error, gc.IsNil)
+	}
+}
+
+func (s *LeadershipSuite) TestRePinApplicationsSameEntity(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	tag := names.NewMachineTag("0").String()
+	_, err := s.api.RePinApplications(params.RePinApplicationsParams{SourceTag: tag, TargetTag: tag})
+	c.Assert(err, gc.ErrorMatches, `cannot re-pin applications from "machine-0" to itself`)
+	c.Check(errors.IsBadRequest(err), jc.IsTrue)
+}
+
+func (s *LeadershipSuite) TestRePinApplicationsPinError(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	source := names.NewMachineTag("0")
+	target := names.NewMachineTag("1")
+	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{"mysql": {source}}).Times(2)
+	s.pinner.EXPECT().LeadershipPinReasons().Return(nil)
+	s.pinner.EXPECT().PinLeadership("mysql", target).Return(errorRes)
+
+	res, err := s.api.RePinApplications(params.RePinApplicationsParams{
+		SourceTag: source.String(),
+		TargetTag: target.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Check(res.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
 func (s *LeadershipSuite) TestUnpinMachineApplicationsPartialError(c *gc.C) {
 	defer s.setup(c).Finish()
 
 	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...))
 	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil)
 	s.pinner.EXPECT().UnpinLeadership("redis", s.tag).Return(errorRes)
 	s.pinner.EXPECT().UnpinLeadership("wordpress", s.tag).Return(nil)
@@ -99,47 +624,895 @@ func (s *LeadershipSuite) TestUnpinMachineApplicationsPartialError(c *gc.C) {
 	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
 }
 
-func (s *LeadershipSuite) TestPermissionDenied(c *gc.C) {
-	s.tag = names.NewUserTag("some-random-cat")
+func (s *LeadershipSuite) TestUnpinMachineApplicationsNotPinnedBySelf(c *gc.C) {
 	defer s.setup(c).Finish()
 
-	_, err := s.api.PinMachineApplications()
-	c.Assert(err, gc.ErrorMatches, "permission denied")
+	// Pins held by other entities are left in place,
+	// and unpinning reports success without affecting them.
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql": {s.tag, names.NewMachineTag("1")},
+		"redis": {names.NewMachineTag("1")},
+	})
+	s.pinner.EXPECT().UnpinLeadership("mysql", s.tag).Return(nil)
 
-	_, err = s.api.UnpinMachineApplications()
-	c.Assert(err, gc.ErrorMatches, "permission denied")
+	res, err := s.api.UnpinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.pinApplicationsSuccessResults()
+	results[1].Info = `leadership not pinned by "machine-0"`
+	results[2].Info = `leadership not pinned by "machine-0"`
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
 }
 
-func (s *LeadershipSuite) setup(c *gc.C) *gomock.Controller {
-	ctrl := gomock.NewController(c)
+func (s *LeadershipSuite) TestPinApplicationOnMachineSuccess(c *gc.C) {
+	defer s.setup(c).Finish()
 
-	s.backend = commonmocks.NewMockLeadershipPinningBackend(ctrl)
-	s.machine = commonmocks.NewMockLeadershipMachine(ctrl)
-	s.pinner = mocks.NewMockPinner(ctrl)
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
 
-	s.backend.EXPECT().Machine("0").Return(s.machine, nil).AnyTimes()
-	s.machine.EXPECT().ApplicationNames().Return(s.machineApps, nil).AnyTimes()
+	res, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+	})
+}
 
-	if s.tag == nil {
-		s.tag = names.NewMachineTag("0")
-	}
+func (s *LeadershipSuite) TestPinApplicationOnMachineError(c *gc.C) {
+	defer s.setup(c).Finish()
 
-	var err error
-	s.api, err = common.NewLeadershipPinningAPI(
-		s.backend,
-		names.NewModelTag(utils.MustNewUUID().String()),
-		s.pinner,
-		&apiservertesting.FakeAuthorizer{Tag: s.tag},
-	)
+	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(errorRes)
+
+	res, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+		Error:          common.ServerError(errorRes),
+	})
+}
 
-	return ctrl
+func (s *LeadershipSuite) TestPinApplicationOnMachineNotOnMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("postgresql").String()})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `application "postgresql" on machine "0" not found`)
 }
 
-func (s *LeadershipSuite) pinApplicationsSuccessResults() []params.PinApplicationResult {
+func (s *LeadershipSuite) TestPinMachineApplicationsNoApplications(c *gc.C) {
+	s.machineApps = nil
+	defer s.setup(c).Finish()
+
+	res, err := s.api.PinMachineApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{
+		Results: []params.PinApplicationResult{},
+		Info:    `no applications on machine "0"`,
+	})
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsMachineNotFound(c *gc.C) {
+	s.tag = names.NewMachineTag("1")
+	defer s.setup(c).Finish()
+
+	s.backend.EXPECT().Machine("1").Return(nil, errors.NotFoundf("machine 1"))
+
+	_, err := s.api.PinMachineApplications()
+	c.Assert(err, gc.ErrorMatches, `machine "1" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(params.IsCodeNotFound(common.ServerError(err)), jc.IsTrue)
+}
+
+func (s *LeadershipSuite) TestPinMachineApplicationsNotMachineAgent(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinMachineApplications()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	c.Check(params.IsCodeNotFound(err), jc.IsFalse)
+}
+
+func (s *LeadershipSuite) TestPinMachinesApplications(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	machineTag := names.NewMachineTag("0")
+	s.backend.EXPECT().Machine("1").Return(nil, errors.NotFoundf("machine 1"))
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().PinLeadership(app, machineTag).Return(nil)
+	}
+
+	res, err := s.api.PinMachinesApplications(params.Entities{Entities: []params.Entity{
+		{Tag: machineTag.String()},
+		{Tag: names.NewMachineTag("1").String()},
+		{Tag: names.NewUnitTag("redis/0").String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+
+	appResults := make([]params.PinApplicationResult, len(s.machineApps))
+	for i, app := range s.machineApps {
+		appResults[i] = params.PinApplicationResult{
+			ApplicationTag: names.NewApplicationTag(app).String(),
+			EntityTag:      s.tag.String(),
+		}
+	}
+	c.Check(res.Results[0], gc.DeepEquals, params.PinMachineApplicationsResult{
+		MachineTag: machineTag.String(),
+		Results:    appResults,
+	})
+	c.Check(res.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(res.Results[2].Error, gc.ErrorMatches, `"unit-redis-0" is not a valid machine tag`)
+}
+
+func (s *LeadershipSuite) TestPinMachinesApplicationsNoApplications(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	machine := commonmocks.NewMockLeadershipMachine(ctrl)
+	s.backend.EXPECT().Machine("1").Return(machine, nil)
+	machine.EXPECT().ApplicationNames().Return(nil, nil)
+
+	res, err := s.api.PinMachinesApplications(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewMachineTag("1").String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Results, gc.DeepEquals, []params.PinMachineApplicationsResult{{
+		MachineTag: names.NewMachineTag("1").String(),
+		Results:    []params.PinApplicationResult{},
+		Info:       `no applications on machine "1"`,
+	}})
+}
+
+func (s *LeadershipSuite) TestPinMachinesApplicationsMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinMachinesApplications(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewMachineTag("1").String()},
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestPinApplicationOnMachineBadTag(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewUnitTag("redis/0").String()})
+	c.Assert(err, gc.ErrorMatches, `"unit-redis-0" is not a valid application tag`)
+}
+
+func (s *LeadershipSuite) TestPinnedLeadershipMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership())
+	s.pinner.EXPECT().LeadershipPinReasons().Return(nil)
+
+	res, err := s.api.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinnedLeadershipResult{
+		Result: map[string][]string{
+			"mysql": {"machine-0"},
+			"redis": {"machine-0"},
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinnedLeadershipModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership())
+	s.pinner.EXPECT().LeadershipPinReasons().Return(map[string]map[names.Tag]string{
+		"redis": {names.NewMachineTag("0"): "series upgrade"},
+	})
+
+	res, err := s.api.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinnedLeadershipResult{
+		Result: map[string][]string{
+			"mysql":     {"machine-0", "machine-1"},
+			"redis":     {"machine-0"},
+			"wordpress": {"machine-1"},
+		},
+		Reasons: map[string]map[string]string{
+			"redis": {"machine-0": "series upgrade"},
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinCounts(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		"mysql": {names.NewMachineTag("0"), names.NewMachineTag("1"), names.NewMachineTag("2")},
+		"redis": {names.NewMachineTag("0")},
+	})
+
+	res, err := s.api.PinCounts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinCountsResult{
+		Counts: map[string]int{
+			names.NewApplicationTag("mysql").String(): 3,
+			names.NewApplicationTag("redis").String(): 1,
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinCountsMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinCounts()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestIsApplicationPinnedModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership()).Times(2)
+
+	res, err := s.api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("mysql").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.ApplicationPinnedResult{
+		Pinned:     true,
+		EntityTags: []string{"machine-0", "machine-1"},
+	})
+
+	res, err = s.api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("postgres").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.ApplicationPinnedResult{})
+}
+
+func (s *LeadershipSuite) TestIsApplicationPinnedMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership())
+
+	res, err := s.api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("wordpress").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.ApplicationPinnedResult{
+		Pinned:     true,
+		EntityTags: []string{"machine-1"},
+	})
+}
+
+func (s *LeadershipSuite) TestIsApplicationPinnedMachineAgentOtherApplication(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("postgres").String()})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *LeadershipSuite) TestWatchLeadershipPinsMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership()).AnyTimes()
+
+	res, err := s.api.WatchLeadershipPins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Check(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *LeadershipSuite) TestWatchLeadershipPinsModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedLeadership()).AnyTimes()
+
+	res, err := s.api.WatchLeadershipPins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Check(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *LeadershipSuite) TestPinApplicationModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+
+	res, err := s.api.PinApplication(params.PinApplicationParams{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+	})
+}
+
+func (s *LeadershipSuite) TestPinAndReportLeader(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+	s.backend.EXPECT().ApplicationLeaders().Return(map[string]string{"redis": "redis/1"}, nil)
+
+	res, err := s.api.PinAndReportLeader(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationLeaderResult{
+		Pin: params.PinApplicationResult{
+			ApplicationTag: names.NewApplicationTag("redis").String(),
+			EntityTag:      s.tag.String(),
+		},
+		LeaderElected: true,
+		LeaderTag:     names.NewUnitTag("redis/1").String(),
+	})
+}
+
+func (s *LeadershipSuite) TestPinAndReportLeaderNoLeader(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+	s.backend.EXPECT().ApplicationLeaders().Return(map[string]string{"mysql": "mysql/0"}, nil)
+
+	res, err := s.api.PinAndReportLeader(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationLeaderResult{
+		Pin: params.PinApplicationResult{
+			ApplicationTag: names.NewApplicationTag("redis").String(),
+			EntityTag:      s.tag.String(),
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinAndReportLeaderPinError(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(errorRes)
+
+	res, err := s.api.PinAndReportLeader(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationLeaderResult{
+		Pin: params.PinApplicationResult{
+			ApplicationTag: names.NewApplicationTag("redis").String(),
+			EntityTag:      s.tag.String(),
+			Error:          common.ServerError(errorRes),
+		},
+	})
+}
+
+func (s *LeadershipSuite) TestPinApplicationWithReasonModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinLeadershipWithReason("redis", s.tag, "maintenance").Return(nil)
+
+	res, err := s.api.PinApplication(params.PinApplicationParams{
+		Tag:    names.NewApplicationTag("redis").String(),
+		Reason: "maintenance",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+	})
+}
+
+func (s *LeadershipSuite) TestPinAllApplications(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	errorRes := errors.New("boom")
+	s.backend.EXPECT().AllApplicationNames().Return([]string{"wordpress", "mysql", "redis"}, nil)
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{"mysql": {s.tag}})
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(errorRes)
+	s.pinner.EXPECT().PinLeadership("wordpress", s.tag).Return(nil)
+
+	res, err := s.api.PinAllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := s.pinApplicationsSuccessResults()
+	results[0].Info = `leadership already pinned by "user-admin"`
+	results[1].Error = common.ServerError(errorRes)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: results})
+}
+
+func (s *LeadershipSuite) TestPinAllApplicationsMachineAgent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinAllApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestPinApplicationByName(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(nil)
+
+	res, err := s.api.PinApplicationByName(params.ApplicationNameParams{ApplicationName: "redis"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+	})
+}
+
+func (s *LeadershipSuite) TestUnpinApplicationByName(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag("redis"))
+	s.pinner.EXPECT().UnpinLeadership("redis", s.tag).Return(nil)
+
+	res, err := s.api.UnpinApplicationByName(params.ApplicationNameParams{ApplicationName: "redis"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+	})
+}
+
+func (s *LeadershipSuite) TestPinApplicationByNameInvalid(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinApplicationByName(params.ApplicationNameParams{ApplicationName: "redis/0"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (s *LeadershipSuite) TestUnpinApplicationModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag("redis"))
+	s.pinner.EXPECT().UnpinLeadership("redis", s.tag).Return(errorRes)
+
+	res, err := s.api.UnpinApplication(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("redis").String(),
+		EntityTag:      s.tag.String(),
+		Error:          common.ServerError(errorRes),
+	})
+}
+
+func (s *LeadershipSuite) TestPinApplicationsModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	errorRes := errors.New("boom")
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("mysql", s.tag).Return(nil)
+	s.pinner.EXPECT().PinLeadership("redis", s.tag).Return(errorRes)
+
+	res, err := s.api.PinApplications(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag("mysql").String()},
+		{Tag: names.NewApplicationTag("redis").String()},
+		{Tag: names.NewUnitTag("wordpress/0").String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: []params.PinApplicationResult{
+		{
+			ApplicationTag: names.NewApplicationTag("mysql").String(),
+			EntityTag:      s.tag.String(),
+		},
+		{
+			ApplicationTag: names.NewApplicationTag("redis").String(),
+			EntityTag:      s.tag.String(),
+			Error:          common.ServerError(errorRes),
+		},
+		{
+			ApplicationTag: names.NewUnitTag("wordpress/0").String(),
+			EntityTag:      s.tag.String(),
+			Error: &params.Error{
+				Message: `"unit-wordpress-0" is not a valid application tag`,
+				Code:    params.CodeBadRequest,
+			},
+		},
+	}})
+}
+
+func (s *LeadershipSuite) TestUnpinApplicationsModelAdmin(c *gc.C) {
+	s.tag = names.NewUserTag("admin")
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(s.pinnedByTag(s.machineApps...))
+	for _, app := range s.machineApps {
+		s.pinner.EXPECT().UnpinLeadership(app, s.tag).Return(nil)
+	}
+
+	entities := make([]params.Entity, len(s.machineApps))
+	for i, app := range s.machineApps {
+		entities[i] = params.Entity{Tag: names.NewApplicationTag(app).String()}
+	}
+
+	res, err := s.api.UnpinApplications(params.Entities{Entities: entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationsResults{Results: s.pinApplicationsSuccessResults()})
+}
+
+func (s *LeadershipSuite) TestPinApplicationMachineAgentDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinApplication(params.PinApplicationParams{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.UnpinApplication(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.PinApplications(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.UnpinApplications(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestErrorCodes(c *gc.C) {
+	admin := names.NewUserTag("admin")
+	machine := names.NewMachineTag("0")
+	redis := names.NewApplicationTag("redis").String()
+
+	for i, test := range []struct {
+		about  string
+		tag    names.Tag
+		expect func()
+		call   func() error
+		code   string
+	}{{
+		about: "permission denied",
+		tag:   names.NewUserTag("some-random-cat"),
+		call: func() error {
+			_, err := s.api.PinApplication(params.PinApplicationParams{Tag: redis})
+			return err
+		},
+		code: params.CodeUnauthorized,
+	}, {
+		about: "machine not found",
+		tag:   names.NewMachineTag("1"),
+		expect: func() {
+			s.backend.EXPECT().Machine("1").Return(nil, errors.NotFoundf("machine 1"))
+		},
+		call: func() error {
+			_, err := s.api.PinMachineApplications()
+			return err
+		},
+		code: params.CodeNotFound,
+	}, {
+		about: "application not on machine",
+		tag:   machine,
+		call: func() error {
+			_, err := s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("postgres").String()})
+			return err
+		},
+		code: params.CodeNotFound,
+	}, {
+		about: "invalid application tag",
+		tag:   admin,
+		call: func() error {
+			_, err := s.api.PinApplication(params.PinApplicationParams{Tag: names.NewUnitTag("redis/0").String()})
+			return err
+		},
+		code: params.CodeBadRequest,
+	}, {
+		about: "invalid TTL",
+		tag:   machine,
+		call: func() error {
+			_, err := s.api.PinMachineApplicationsWithTTL(params.PinLeadershipTTLParams{DurationSeconds: -1})
+			return err
+		},
+		code: params.CodeBadRequest,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		s.tag = test.tag
+		ctrl := s.setup(c)
+		if test.expect != nil {
+			test.expect()
+		}
+		err := test.call()
+		c.Check(params.ErrCode(common.ServerError(err)), gc.Equals, test.code)
+		ctrl.Finish()
+	}
+}
+
+func (s *LeadershipSuite) TestPermissionDenied(c *gc.C) {
+	s.tag = names.NewUserTag("some-random-cat")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.PinMachineApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.UnpinMachineApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.PinnedLeadership()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.PinMachineApplicationsWithTTL(params.PinLeadershipTTLParams{DurationSeconds: 60})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.WatchLeadershipPins()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.api.PinApplication(params.PinApplicationParams{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) TestNonMachineTagPermissionDenied(c *gc.C) {
+	s.tag = names.NewUnitTag("redis/0")
+	defer s.setup(c).Finish()
+
+	// An authorizer that claims a machine agent, but has a unit tag,
+	// must not be used to look up a machine.
+	api, err := common.NewLeadershipPinningAPI(
+		s.backend,
+		names.NewModelTag(utils.MustNewUUID().String()),
+		s.pinner,
+		s.resources,
+		machineAgentAuthorizer{apiservertesting.FakeAuthorizer{Tag: s.tag}},
+		nil,
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.PinMachineApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.UnpinMachineApplications()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.ClearMachinePins()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.UnpinMachineApplicationsWithDelay(params.UnpinLeadershipStaggerParams{StaggerSeconds: 1})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.PinCounts()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.PinApplicationByName(params.ApplicationNameParams{ApplicationName: "redis"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.UnpinApplicationByName(params.ApplicationNameParams{ApplicationName: "redis"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.IsApplicationPinned(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.RePinApplications(params.RePinApplicationsParams{
+		SourceTag: names.NewMachineTag("0").String(),
+		TargetTag: names.NewMachineTag("1").String(),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.PinAndReportLeader(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = api.PinApplicationOnMachine(params.Entity{Tag: names.NewApplicationTag("redis").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.backend = commonmocks.NewMockLeadershipPinningBackend(ctrl)
+	s.machine = commonmocks.NewMockLeadershipMachine(ctrl)
+	s.pinner = mocks.NewMockPinner(ctrl)
+
+	s.backend.EXPECT().Machine("0").Return(s.machine, nil).AnyTimes()
+	s.machine.EXPECT().ApplicationNames().Return(s.machineApps, nil).AnyTimes()
+	s.pinner.EXPECT().LeadershipPinExpiries().Return(s.pinExpiries).AnyTimes()
+
+	if s.tag == nil {
+		s.tag = names.NewMachineTag("0")
+	}
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	s.modelTag = names.NewModelTag(utils.MustNewUUID().String())
+	s.api = s.newAPI(c, s.modelTag)
+
+	return ctrl
+}
+
+// newAPI returns a leadership pinning API for the input model,
+// authorised as the suite's entity.
+func (s *LeadershipSuite) newAPI(c *gc.C, modelTag names.ModelTag) common.LeadershipPinningAPI {
+	api, err := common.NewLeadershipPinningAPI(
+		s.backend,
+		modelTag,
+		s.pinner,
+		s.resources,
+		&apiservertesting.FakeAuthorizer{Tag: s.tag},
+		s.limiter,
+		s.machineCache,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *LeadershipSuite) confirmedPinResults() []params.ConfirmedPinResult {
+	pinResults := s.pinApplicationsSuccessResults()
+	results := make([]params.ConfirmedPinResult, len(pinResults))
+	for i, pinResult := range pinResults {
+		results[i] = params.ConfirmedPinResult{Result: pinResult}
+	}
+	return results
+}
+
+func (s *LeadershipSuite) pinApplicationsSuccessResults() []params.PinApplicationResult {
 	results := make([]params.PinApplicationResult, len(s.machineApps))
 	for i, app := range s.machineApps {
-		results[i] = params.PinApplicationResult{ApplicationTag: names.NewApplicationTag(app).String()}
+		results[i] = params.PinApplicationResult{
+			ApplicationTag: names.NewApplicationTag(app).String(),
+			EntityTag:      s.tag.String(),
+		}
 	}
 	return results
 }
+
+// pinnedByTag returns pins held by the suite's tag for the input applications.
+func (s *LeadershipSuite) pinnedByTag(apps ...string) map[string][]names.Tag {
+	pinned := make(map[string][]names.Tag, len(apps))
+	for _, app := range apps {
+		pinned[app] = []names.Tag{s.tag}
+	}
+	return pinned
+}
+
+func (s *LeadershipSuite) pinnedLeadership() map[string][]names.Tag {
+	return map[string][]names.Tag{
+		"mysql":     {names.NewMachineTag("0"), names.NewMachineTag("1")},
+		"redis":     {names.NewMachineTag("0")},
+		"wordpress": {names.NewMachineTag("1")},
+	}
+}
+
+// machineAgentAuthorizer is a FakeAuthorizer that reports the
+// authenticated entity as a machine agent, regardless of its tag.
+// fixedMachineCache is a facade.MachineApplicationsCache
+// that always returns the same application names.
+type fixedMachineCache []string
+
+func (c fixedMachineCache) ApplicationNames(_, _ string, _ func() ([]string, error)) ([]string, error) {
+	return c, nil
+}
+
+type machineAgentAuthorizer struct {
+	apiservertesting.FakeAuthorizer
+}
+
+func (machineAgentAuthorizer) AuthMachineAgent() bool {
+	return true
+}
+
+type leadershipFacadeSuite struct {
+	statetesting.StateSuite
+
+	pinner    *mocks.MockPinner
+	resources *common.Resources
+}
+
+var _ = gc.Suite(&leadershipFacadeSuite{})
+
+func (s *leadershipFacadeSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+}
+
+func (s *leadershipFacadeSuite) TestModelAdminCanPin(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.pinner.EXPECT().PinnedLeadership().Return(nil)
+	s.pinner.EXPECT().PinLeadership("mysql", s.Owner).Return(nil)
+
+	api, err := common.NewLeadershipPinningFacade(s.context(s.Owner))
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := api.PinApplication(params.PinApplicationParams{Tag: names.NewApplicationTag("mysql").String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, params.PinApplicationResult{
+		ApplicationTag: names.NewApplicationTag("mysql").String(),
+		EntityTag:      s.Owner.String(),
+	})
+}
+
+func (s *leadershipFacadeSuite) TestNonAdminUserDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	api, err := common.NewLeadershipPinningFacade(s.context(names.NewUserTag("some-random-cat")))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.PinApplication(params.PinApplicationParams{Tag: names.NewApplicationTag("mysql").String()})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *leadershipFacadeSuite) TestUnitAgentDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := common.NewLeadershipPinningFacade(s.context(names.NewUnitTag("mysql/0")))
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *leadershipFacadeSuite) TestWatchLeadershipPinsFiresOnPinChange(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.PatchValue(&common.PinnedLeadershipPollInterval, coretesting.ShortWait)
+
+	machine := s.Factory.MakeMachine(c, nil)
+	app := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+
+	gomock.InOrder(
+		s.pinner.EXPECT().PinnedLeadership().Return(nil),
+		s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+			app.Name(): {machine.Tag()},
+		}).AnyTimes(),
+	)
+
+	wc := s.watchLeadershipPins(c, machine.Tag())
+	defer statetesting.AssertStop(c, wc.Watcher)
+	wc.AssertOneChange()
+}
+
+func (s *leadershipFacadeSuite) TestWatchLeadershipPinsIncludesApplicationsDeployedLater(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.PatchValue(&common.PinnedLeadershipPollInterval, coretesting.ShortWait)
+
+	machine := s.Factory.MakeMachine(c, nil)
+	app := s.Factory.MakeApplication(c, nil)
+
+	s.pinner.EXPECT().PinnedLeadership().Return(map[string][]names.Tag{
+		app.Name(): {names.NewMachineTag("42")},
+	}).AnyTimes()
+
+	wc := s.watchLeadershipPins(c, machine.Tag())
+	defer statetesting.AssertStop(c, wc.Watcher)
+	wc.AssertNoChange()
+
+	// The application's existing pin is reported once
+	// one of its units is deployed to the machine.
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+	wc.AssertOneChange()
+}
+
+// watchLeadershipPins calls WatchLeadershipPins on a facade created for the
+// input entity, returning the registered watcher with its initial event consumed.
+func (s *leadershipFacadeSuite) watchLeadershipPins(c *gc.C, tag names.Tag) statetesting.NotifyWatcherC {
+	api, err := common.NewLeadershipPinningFacade(s.context(tag))
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := api.WatchLeadershipPins()
+	c.Assert(err, jc.ErrorIsNil)
+	w, ok := s.resources.Get(res.NotifyWatcherId).(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	return statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, w)
+}
+
+func (s *leadershipFacadeSuite) context(tag names.Tag) facadetest.Context {
+	auth := apiservertesting.FakeAuthorizer{Tag: tag}
+	if tag == s.Owner {
+		auth.AdminTag = s.Owner
+	}
+	return facadetest.Context{
+		State_:            s.State,
+		Resources_:        s.resources,
+		Auth_:             auth,
+		LeadershipPinner_: s.pinner,
+	}
+}
+
+func (s *leadershipFacadeSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.pinner = mocks.NewMockPinner(ctrl)
+	s.pinner.EXPECT().LeadershipPinExpiries().Return(nil).AnyTimes()
+	return ctrl
+}
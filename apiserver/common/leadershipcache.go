@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// MachineApplicationsCacheTTL is the duration for which the application names
+// for a machine are reused by leadership pin and unpin operations before
+// being read again from state.
+var MachineApplicationsCacheTTL = 2 * time.Second
+
+// machineApplicationsKey identifies a machine in a model.
+type machineApplicationsKey struct {
+	modelUUID string
+	machineID string
+}
+
+// cachedApplicationNames holds the application names read for a machine,
+// along with the time at which they cease to be valid.
+type cachedApplicationNames struct {
+	names  []string
+	expiry time.Time
+}
+
+// MachineApplicationsCache is a facade.MachineApplicationsCache that reuses
+// application names read for a machine within its TTL. It is safe for
+// concurrent use, so that a single cache can be shared by all connections
+// to the API server.
+type MachineApplicationsCache struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	cache map[machineApplicationsKey]cachedApplicationNames
+}
+
+// NewMachineApplicationsCache returns a MachineApplicationsCache that caches
+// machine application names for the input duration.
+// A non-positive TTL disables caching.
+func NewMachineApplicationsCache(clock clock.Clock, ttl time.Duration) *MachineApplicationsCache {
+	return &MachineApplicationsCache{
+		clock: clock,
+		ttl:   ttl,
+		cache: make(map[machineApplicationsKey]cachedApplicationNames),
+	}
+}
+
+// ApplicationNames (facade.MachineApplicationsCache) returns the application
+// names for the input machine in the input model. If none were cached within
+// the TTL, they are read using the input function and cached.
+// Errors are never cached.
+func (c *MachineApplicationsCache) ApplicationNames(
+	modelUUID, machineID string, read func() ([]string, error),
+) ([]string, error) {
+	if c.ttl <= 0 {
+		return read()
+	}
+
+	key := machineApplicationsKey{modelUUID: modelUUID, machineID: machineID}
+	if names, ok := c.cached(key); ok {
+		return names, nil
+	}
+	names, err := read()
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, names)
+	return names, nil
+}
+
+// cached returns the application names cached for the input key,
+// and true if they have not yet expired.
+func (c *MachineApplicationsCache) cached(key machineApplicationsKey) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok || !c.clock.Now().Before(entry.expiry) {
+		return nil, false
+	}
+	return copyStrings(entry.names), true
+}
+
+// store caches the input application names for the input key.
+// Expired entries for other machines are discarded, so that the
+// cache does not grow with machines that are no longer queried.
+func (c *MachineApplicationsCache) store(key machineApplicationsKey, names []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for k, entry := range c.cache {
+		if !now.Before(entry.expiry) {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[key] = cachedApplicationNames{
+		names:  copyStrings(names),
+		expiry: now.Add(c.ttl),
+	}
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type machineApplicationsCacheSuite struct {
+	coretesting.BaseSuite
+
+	clock *testclock.Clock
+	reads int
+	names []string
+}
+
+var _ = gc.Suite(&machineApplicationsCacheSuite{})
+
+func (s *machineApplicationsCacheSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.reads = 0
+	s.names = []string{"mysql", "redis"}
+}
+
+func (s *machineApplicationsCacheSuite) TestApplicationNamesCached(c *gc.C) {
+	cache := common.NewMachineApplicationsCache(s.clock, time.Second)
+	for i := 0; i < 3; i++ {
+		s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+		s.clock.Advance(time.Second / 4)
+	}
+	c.Check(s.reads, gc.Equals, 1)
+}
+
+func (s *machineApplicationsCacheSuite) TestCacheInvalidatedAfterTTL(c *gc.C) {
+	cache := common.NewMachineApplicationsCache(s.clock, time.Second)
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+
+	s.names = []string{"mysql"}
+	s.clock.Advance(time.Second)
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql"})
+	c.Check(s.reads, gc.Equals, 2)
+}
+
+func (s *machineApplicationsCacheSuite) TestCacheKeyedOnModelAndMachine(c *gc.C) {
+	cache := common.NewMachineApplicationsCache(s.clock, time.Second)
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+	s.assertApplicationNames(c, cache, "model", "1", []string{"mysql", "redis"})
+	s.assertApplicationNames(c, cache, "other-model", "0", []string{"mysql", "redis"})
+	c.Check(s.reads, gc.Equals, 3)
+}
+
+func (s *machineApplicationsCacheSuite) TestErrorsNotCached(c *gc.C) {
+	cache := common.NewMachineApplicationsCache(s.clock, time.Second)
+	_, err := cache.ApplicationNames("model", "0", func() ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+	c.Check(s.reads, gc.Equals, 1)
+}
+
+func (s *machineApplicationsCacheSuite) TestZeroTTLDisablesCache(c *gc.C) {
+	cache := common.NewMachineApplicationsCache(s.clock, 0)
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+	s.assertApplicationNames(c, cache, "model", "0", []string{"mysql", "redis"})
+	c.Check(s.reads, gc.Equals, 2)
+}
+
+func (s *machineApplicationsCacheSuite) assertApplicationNames(
+	c *gc.C, cache *common.MachineApplicationsCache, modelUUID, machineID string, expected []string,
+) {
+	names, err := cache.ApplicationNames(modelUUID, machineID, func() ([]string, error) {
+		s.reads++
+		return s.names, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, jc.SameContents, expected)
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// PinnedLeadershipPollInterval is the frequency at which
+// leadership pins are checked for changes by the pin watcher.
+var PinnedLeadershipPollInterval = 5 * time.Second
+
+// pinnedLeadershipWatcher is a notify watcher that fires when the
+// result of polling its source of leadership pins changes.
+type pinnedLeadershipWatcher struct {
+	tomb     tomb.Tomb
+	out      chan struct{}
+	pinned   func() (map[string][]string, error)
+	clock    clock.Clock
+	interval time.Duration
+}
+
+// NewPinnedLeadershipWatcher returns a notify watcher that polls the input
+// source of leadership pins at the input interval, firing when it changes.
+// There is always an initial event. The watcher dies with any error
+// returned by the source.
+func NewPinnedLeadershipWatcher(
+	pinned func() (map[string][]string, error), clock clock.Clock, interval time.Duration,
+) state.NotifyWatcher {
+	w := &pinnedLeadershipWatcher{
+		out:      make(chan struct{}),
+		pinned:   pinned,
+		clock:    clock,
+		interval: interval,
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Stop stops the watcher, and returns any error encountered while running
+// or shutting down.
+func (w *pinnedLeadershipWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Kill kills the watcher without waiting for it to shut down.
+func (w *pinnedLeadershipWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait waits for the watcher to die and returns any
+// error encountered when it was running.
+func (w *pinnedLeadershipWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Err returns any error encountered while running or shutting down, or
+// tomb.ErrStillAlive if the watcher is still running.
+func (w *pinnedLeadershipWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+// Changes returns the event channel for the watcher.
+func (w *pinnedLeadershipWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *pinnedLeadershipWatcher) loop() error {
+	last, err := w.pinned()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.clock.After(w.interval):
+			current, err := w.pinned()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !reflect.DeepEqual(current, last) {
+				last = current
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type pinnedLeadershipWatcherSuite struct {
+	coretesting.BaseSuite
+
+	mu     sync.Mutex
+	pinned map[string][]string
+	err    error
+}
+
+var _ = gc.Suite(&pinnedLeadershipWatcherSuite{})
+
+func (s *pinnedLeadershipWatcherSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.pinned = map[string][]string{"redis": {"machine-0"}}
+	s.err = nil
+}
+
+func (s *pinnedLeadershipWatcherSuite) TestChanges(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	w := common.NewPinnedLeadershipWatcher(s.getPinned, clock, time.Second)
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertOneChange()
+
+	// No change to pins means no event.
+	s.advance(c, clock)
+	wc.AssertNoChange()
+
+	s.setPinned(map[string][]string{"redis": {"machine-0", "machine-1"}})
+	s.advance(c, clock)
+	wc.AssertOneChange()
+}
+
+func (s *pinnedLeadershipWatcherSuite) TestSourceError(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	w := common.NewPinnedLeadershipWatcher(s.getPinned, clock, time.Second)
+
+	wc := statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertOneChange()
+
+	s.setErr(errors.New("boom"))
+	s.advance(c, clock)
+	c.Check(w.Wait(), gc.ErrorMatches, "boom")
+	wc.AssertClosed()
+}
+
+func (s *pinnedLeadershipWatcherSuite) TestStop(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	w := common.NewPinnedLeadershipWatcher(s.getPinned, clock, time.Second)
+
+	wc := statetesting.NewNotifyWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertOneChange()
+	statetesting.AssertCanStopWhenSending(c, w)
+	wc.AssertClosed()
+}
+
+func (s *pinnedLeadershipWatcherSuite) advance(c *gc.C, clock *testclock.Clock) {
+	err := clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *pinnedLeadershipWatcherSuite) getPinned() (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pinned, s.err
+}
+
+func (s *pinnedLeadershipWatcherSuite) setPinned(pinned map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pinned = pinned
+}
+
+func (s *pinnedLeadershipWatcherSuite) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
@@ -33,6 +33,32 @@ func (m *MockLeadershipPinningBackend) EXPECT() *MockLeadershipPinningBackendMoc
 	return m.recorder
 }
 
+// AllApplicationNames mocks base method
+func (m *MockLeadershipPinningBackend) AllApplicationNames() ([]string, error) {
+	ret := m.ctrl.Call(m, "AllApplicationNames")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllApplicationNames indicates an expected call of AllApplicationNames
+func (mr *MockLeadershipPinningBackendMockRecorder) AllApplicationNames() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllApplicationNames", reflect.TypeOf((*MockLeadershipPinningBackend)(nil).AllApplicationNames))
+}
+
+// ApplicationLeaders mocks base method
+func (m *MockLeadershipPinningBackend) ApplicationLeaders() (map[string]string, error) {
+	ret := m.ctrl.Call(m, "ApplicationLeaders")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationLeaders indicates an expected call of ApplicationLeaders
+func (mr *MockLeadershipPinningBackendMockRecorder) ApplicationLeaders() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationLeaders", reflect.TypeOf((*MockLeadershipPinningBackend)(nil).ApplicationLeaders))
+}
+
 // Machine mocks base method
 func (m *MockLeadershipPinningBackend) Machine(arg0 string) (common.LeadershipMachine, error) {
 	ret := m.ctrl.Call(m, "Machine", arg0)
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/ratelimit"
+	"gopkg.in/juju/names.v2"
+)
+
+// rateLimitClock adapts clock.Clock to ratelimit.Clock.
+type rateLimitClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c rateLimitClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
+
+// entityRateLimitKey identifies an entity in a model.
+type entityRateLimitKey struct {
+	modelUUID string
+	tag       names.Tag
+}
+
+// entityBucket is the token bucket for an entity,
+// along with the time at which a token was last taken from it.
+type entityBucket struct {
+	bucket   *ratelimit.Bucket
+	lastTake time.Time
+}
+
+// EntityRateLimiter is a facade.RateLimiter that keeps a separate
+// token bucket for each entity in each model. It is safe for concurrent
+// use, so that a single limiter can be shared by all connections to the
+// API server. Buckets that have fully refilled are discarded.
+type EntityRateLimiter struct {
+	clock  clock.Clock
+	burst  int64
+	refill time.Duration
+
+	mu        sync.Mutex
+	buckets   map[entityRateLimitKey]*entityBucket
+	nextPrune time.Time
+}
+
+// NewEntityRateLimiter returns an EntityRateLimiter that allows each
+// entity the input burst of operations, after which a further operation
+// is allowed at each refill interval.
+func NewEntityRateLimiter(clock clock.Clock, burst int64, refill time.Duration) *EntityRateLimiter {
+	return &EntityRateLimiter{
+		clock:     clock,
+		burst:     burst,
+		refill:    refill,
+		buckets:   make(map[entityRateLimitKey]*entityBucket),
+		nextPrune: clock.Now().Add(fullRefill(burst, refill)),
+	}
+}
+
+// Allow (facade.RateLimiter) returns true if the input entity has not
+// exceeded its rate limit in the input model, consuming one operation
+// from its allowance.
+func (l *EntityRateLimiter) Allow(modelUUID string, tag names.Tag) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.prune(now)
+
+	key := entityRateLimitKey{modelUUID: modelUUID, tag: tag}
+	entry, ok := l.buckets[key]
+	if !ok {
+		entry = &entityBucket{
+			bucket: ratelimit.NewBucketWithClock(l.refill, l.burst, rateLimitClock{l.clock}),
+		}
+		l.buckets[key] = entry
+	}
+	entry.lastTake = now
+	return entry.bucket.TakeAvailable(1) == 1
+}
+
+// prune discards buckets that have fully refilled since a token was last
+// taken from them, as they are indistinguishable from new buckets.
+// To avoid scanning the buckets on every call, it does so at most once
+// for each period in which a bucket can refill.
+// It must be called with the mutex held.
+func (l *EntityRateLimiter) prune(now time.Time) {
+	if now.Before(l.nextPrune) {
+		return
+	}
+	full := fullRefill(l.burst, l.refill)
+	for key, entry := range l.buckets {
+		if now.Sub(entry.lastTake) >= full {
+			delete(l.buckets, key)
+		}
+	}
+	l.nextPrune = now.Add(full)
+}
+
+// fullRefill returns the time taken for an empty
+// bucket with the input parameters to refill.
+func fullRefill(burst int64, refill time.Duration) time.Duration {
+	return time.Duration(burst) * refill
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type entityRateLimiterSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&entityRateLimiterSuite{})
+
+func (s *entityRateLimiterSuite) TestAllowPerEntity(c *gc.C) {
+	limiter := common.NewEntityRateLimiter(testclock.NewClock(time.Now()), 2, time.Second)
+
+	m0 := names.NewMachineTag("0")
+	m1 := names.NewMachineTag("1")
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m0), jc.IsFalse)
+
+	// Each entity has its own allowance.
+	c.Check(limiter.Allow("model", m1), jc.IsTrue)
+}
+
+func (s *entityRateLimiterSuite) TestAllowPerModel(c *gc.C) {
+	limiter := common.NewEntityRateLimiter(testclock.NewClock(time.Now()), 1, time.Second)
+
+	// Machine tags are not unique across models, so the
+	// same tag in each model has its own allowance.
+	m0 := names.NewMachineTag("0")
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m0), jc.IsFalse)
+	c.Check(limiter.Allow("other-model", m0), jc.IsTrue)
+}
+
+func (s *entityRateLimiterSuite) TestAllowAfterRefill(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	limiter := common.NewEntityRateLimiter(clock, 1, time.Second)
+
+	m0 := names.NewMachineTag("0")
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m0), jc.IsFalse)
+
+	clock.Advance(time.Second)
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m0), jc.IsFalse)
+}
+
+func (s *entityRateLimiterSuite) TestRefilledBucketsDiscarded(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	limiter := common.NewEntityRateLimiter(clock, 2, time.Second)
+
+	m0 := names.NewMachineTag("0")
+	m1 := names.NewMachineTag("1")
+	c.Check(limiter.Allow("model", m0), jc.IsTrue)
+	c.Check(limiter.Allow("model", m1), jc.IsTrue)
+	c.Check(common.EntityRateLimiterSize(limiter), gc.Equals, 2)
+
+	// Once m0's bucket has refilled, it is discarded
+	// the next time the limiter is used.
+	clock.Advance(2 * time.Second)
+	c.Check(limiter.Allow("model", m1), jc.IsTrue)
+	c.Check(common.EntityRateLimiterSize(limiter), gc.Equals, 1)
+}
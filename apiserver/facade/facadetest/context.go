@@ -24,6 +24,9 @@ type Context struct {
 	LeadershipChecker_ leadership.Checker
 	LeadershipPinner_  leadership.Pinner
 	SingularClaimer_   lease.Claimer
+
+	LeadershipPinRateLimiter_  facade.RateLimiter
+	LeadershipPinMachineCache_ facade.MachineApplicationsCache
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -90,6 +93,16 @@ func (context Context) LeadershipPinner(modelUUID string) (leadership.Pinner, er
 	return context.LeadershipPinner_, nil
 }
 
+// LeadershipPinRateLimiter implements facade.Context.
+func (context Context) LeadershipPinRateLimiter() facade.RateLimiter {
+	return context.LeadershipPinRateLimiter_
+}
+
+// LeadershipPinMachineCache implements facade.Context.
+func (context Context) LeadershipPinMachineCache() facade.MachineApplicationsCache {
+	return context.LeadershipPinMachineCache_
+}
+
 // SingularClaimer implements facade.Context.
 func (context Context) SingularClaimer() (lease.Claimer, error) {
 	return context.SingularClaimer_, nil
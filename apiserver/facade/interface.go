@@ -91,6 +91,16 @@ type Context interface {
 	// context's model.
 	LeadershipPinner(modelUUID string) (leadership.Pinner, error)
 
+	// LeadershipPinRateLimiter returns the limiter applied to leadership
+	// pin and unpin operations. It is shared by all connections to the
+	// API server, so that it can not be evaded by reconnecting.
+	LeadershipPinRateLimiter() RateLimiter
+
+	// LeadershipPinMachineCache returns the cache of machine application
+	// names used to enumerate applications for leadership pin and unpin
+	// operations. It is shared by all connections to the API server.
+	LeadershipPinMachineCache() MachineApplicationsCache
+
 	// SingularClaimer returns a lease.Claimer for singular leases for
 	// this context's model.
 	SingularClaimer() (lease.Claimer, error)
@@ -176,3 +186,20 @@ type ModelPresence interface {
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
 }
+
+// RateLimiter limits the rate at which entities may perform an operation.
+type RateLimiter interface {
+	// Allow returns true if the input entity in the model with the
+	// input UUID may perform the operation now, consuming part of
+	// its allowance.
+	Allow(modelUUID string, entity names.Tag) bool
+}
+
+// MachineApplicationsCache caches the names of applications
+// represented by units running on machines.
+type MachineApplicationsCache interface {
+	// ApplicationNames returns the names of applications for the input
+	// machine in the input model, using the input function to read
+	// them if they are not cached.
+	ApplicationNames(modelUUID, machineID string, read func() ([]string, error)) ([]string, error)
+}
@@ -26,6 +26,25 @@ type API struct {
 	resources facade.Resources
 }
 
+// APIv1 provides the UpgradeSeries API facade for version 1.
+// It exposes only the leadership pinning methods that were originally
+// served with the upgrade-series methods.
+type APIv1 struct {
+	*API
+}
+
+// NewAPIv1 creates a new instance of the API server for version 1
+// of the UpgradeSeries facade.
+func NewAPIv1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewAPI creates a new instance of the API server for the latest
+// version of the UpgradeSeries facade.
 func NewAPI(ctx facade.Context) (*API, error) {
 	leadership, err := common.NewLeadershipPinningFacade(ctx)
 	if err != nil {
@@ -276,3 +295,71 @@ func (a *API) authAndMachine(e params.Entity, canAccess common.AuthFunc) (common
 	}
 	return a.GetMachine(tag)
 }
+
+// Mask the leadership pinning methods restricted to model admins from the
+// API. The facade may only be used by machine agents, so these would always
+// fail with a permission error; admins use the LeadershipPinning facade.
+// The API reflection code in rpc/rpcreflect/type.go:newMethod skips
+// 2-argument methods, so this removes the methods as far as the RPC
+// machinery is concerned.
+
+// PinAllApplications is only on the LeadershipPinning facade.
+func (*API) PinAllApplications(_, _ struct{}) {}
+
+// PinAndReportLeader is only on the LeadershipPinning facade.
+func (*API) PinAndReportLeader(_, _ struct{}) {}
+
+// PinApplication is only on the LeadershipPinning facade.
+func (*API) PinApplication(_, _ struct{}) {}
+
+// PinApplicationByName is only on the LeadershipPinning facade.
+func (*API) PinApplicationByName(_, _ struct{}) {}
+
+// PinApplications is only on the LeadershipPinning facade.
+func (*API) PinApplications(_, _ struct{}) {}
+
+// PinCounts is only on the LeadershipPinning facade.
+func (*API) PinCounts(_, _ struct{}) {}
+
+// PinMachinesApplications is only on the LeadershipPinning facade.
+func (*API) PinMachinesApplications(_, _ struct{}) {}
+
+// RePinApplications is only on the LeadershipPinning facade.
+func (*API) RePinApplications(_, _ struct{}) {}
+
+// UnpinApplication is only on the LeadershipPinning facade.
+func (*API) UnpinApplication(_, _ struct{}) {}
+
+// UnpinApplicationByName is only on the LeadershipPinning facade.
+func (*API) UnpinApplicationByName(_, _ struct{}) {}
+
+// UnpinApplications is only on the LeadershipPinning facade.
+func (*API) UnpinApplications(_, _ struct{}) {}
+
+// Mask the new machine agent leadership pinning methods from the V1 API.
+// The model admin methods masked from API above are also masked from
+// the V1 API, which embeds it.
+
+// PinnedLeadership isn't on the V1 API.
+func (*APIv1) PinnedLeadership(_, _ struct{}) {}
+
+// IsApplicationPinned isn't on the V1 API.
+func (*APIv1) IsApplicationPinned(_, _ struct{}) {}
+
+// WatchLeadershipPins isn't on the V1 API.
+func (*APIv1) WatchLeadershipPins(_, _ struct{}) {}
+
+// PinMachineApplicationsAndWait isn't on the V1 API.
+func (*APIv1) PinMachineApplicationsAndWait(_, _ struct{}) {}
+
+// UnpinMachineApplicationsWithDelay isn't on the V1 API.
+func (*APIv1) UnpinMachineApplicationsWithDelay(_, _ struct{}) {}
+
+// ClearMachinePins isn't on the V1 API.
+func (*APIv1) ClearMachinePins(_, _ struct{}) {}
+
+// PinMachineApplicationsWithTTL isn't on the V1 API.
+func (*APIv1) PinMachineApplicationsWithTTL(_, _ struct{}) {}
+
+// PinApplicationOnMachine isn't on the V1 API.
+func (*APIv1) PinApplicationOnMachine(_, _ struct{}) {}
@@ -4,7 +4,10 @@
 package upgradeseries_test
 
 import (
+	"reflect"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/collections/set"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -15,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -178,6 +182,49 @@ func (s *upgradeSeriesSuite) TestFinishUpgradeSeriesNotUpgraded(c *gc.C) {
 	})
 }
 
+func (s *upgradeSeriesSuite) TestV1MasksNewLeadershipMethods(c *gc.C) {
+	v1 := set.NewStrings(rpcreflect.ObjTypeOf(reflect.TypeOf(&upgradeseries.APIv1{})).MethodNames()...)
+	v2 := set.NewStrings(rpcreflect.ObjTypeOf(reflect.TypeOf(&upgradeseries.API{})).MethodNames()...)
+
+	c.Check(v1.Contains("PinMachineApplications"), jc.IsTrue)
+	c.Check(v1.Contains("UnpinMachineApplications"), jc.IsTrue)
+	c.Check(v1.Contains("MachineStatus"), jc.IsTrue)
+	c.Check(v1.Contains("ClearMachinePins"), jc.IsFalse)
+	c.Check(v1.Contains("WatchLeadershipPins"), jc.IsFalse)
+
+	c.Check(v2.Difference(v1).SortedValues(), jc.DeepEquals, []string{
+		"ClearMachinePins",
+		"IsApplicationPinned",
+		"PinApplicationOnMachine",
+		"PinMachineApplicationsAndWait",
+		"PinMachineApplicationsWithTTL",
+		"PinnedLeadership",
+		"UnpinMachineApplicationsWithDelay",
+		"WatchLeadershipPins",
+	})
+}
+
+func (s *upgradeSeriesSuite) TestAdminLeadershipMethodsMasked(c *gc.C) {
+	// Methods only usable by model admins are served by the
+	// LeadershipPinning facade, never by UpgradeSeries.
+	v2 := set.NewStrings(rpcreflect.ObjTypeOf(reflect.TypeOf(&upgradeseries.API{})).MethodNames()...)
+	for _, method := range []string{
+		"PinAllApplications",
+		"PinAndReportLeader",
+		"PinApplication",
+		"PinApplicationByName",
+		"PinApplications",
+		"PinCounts",
+		"PinMachinesApplications",
+		"RePinApplications",
+		"UnpinApplication",
+		"UnpinApplicationByName",
+		"UnpinApplications",
+	} {
+		c.Check(v2.Contains(method), jc.IsFalse, gc.Commentf("method %s", method))
+	}
+}
+
 func (s *upgradeSeriesSuite) arrangeTest(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
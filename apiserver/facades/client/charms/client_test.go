@@ -44,7 +44,11 @@ func (ctx *charmsSuiteContext) Hub() facade.Hub             { return nil }
 func (ctx *charmsSuiteContext) LeadershipClaimer(string) (leadership.Claimer, error) { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipChecker() (leadership.Checker, error)       { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipPinner(string) (leadership.Pinner, error)   { return nil, nil }
-func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error)              { return nil, nil }
+func (ctx *charmsSuiteContext) LeadershipPinRateLimiter() facade.RateLimiter         { return nil }
+func (ctx *charmsSuiteContext) LeadershipPinMachineCache() facade.MachineApplicationsCache {
+	return nil
+}
+func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error) { return nil, nil }
 
 func (s *charmsSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
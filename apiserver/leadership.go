@@ -79,8 +79,38 @@ func (m leadershipPinner) PinLeadership(applicationId string, entity names.Tag)
 	return errors.Trace(m.pinner.Pin(applicationId, entity))
 }
 
+// PinLeadershipWithTTL (leadership.Pinner) pins the lease for the input
+// application and entity, to be released after the input duration.
+func (m leadershipPinner) PinLeadershipWithTTL(applicationId string, entity names.Tag, ttl time.Duration) error {
+	return errors.Trace(m.pinner.PinWithTTL(applicationId, entity, ttl))
+}
+
+// PinLeadershipWithReason (leadership.Pinner) pins the lease for the input
+// application and entity, recording the input reason against the pin.
+func (m leadershipPinner) PinLeadershipWithReason(applicationId string, entity names.Tag, reason string) error {
+	return errors.Trace(m.pinner.PinWithReason(applicationId, entity, reason))
+}
+
 // UnpinLeadership (leadership.Pinner) unpins the lease
 // for the input application and entity.
 func (m leadershipPinner) UnpinLeadership(applicationId string, entity names.Tag) error {
 	return errors.Trace(m.pinner.Unpin(applicationId, entity))
 }
+
+// PinnedLeadership (leadership.Pinner) returns applications for which
+// leadership is pinned, along with the entities requiring the pins.
+func (m leadershipPinner) PinnedLeadership() map[string][]names.Tag {
+	return m.pinner.Pinned()
+}
+
+// LeadershipPinExpiries (leadership.Pinner) returns the times at which
+// leadership pins made with a TTL will be released.
+func (m leadershipPinner) LeadershipPinExpiries() map[string]map[names.Tag]time.Time {
+	return m.pinner.PinExpiries()
+}
+
+// LeadershipPinReasons (leadership.Pinner) returns the reasons recorded
+// for leadership pins, keyed on application name and pinning entity.
+func (m leadershipPinner) LeadershipPinReasons() map[string]map[names.Tag]string {
+	return m.pinner.PinReasons()
+}
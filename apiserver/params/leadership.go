@@ -72,6 +72,9 @@ type MergeLeadershipSettingsParam struct {
 type PinApplicationsResults struct {
 	// Results is collection with each application tag and pin/unpin result.
 	Results []PinApplicationResult `json:"results"`
+	// Info holds information about a call that succeeded without operating
+	// on any applications, such as for a machine with no units.
+	Info string `json:"info,omitempty"`
 }
 
 // PinApplicationResult represents the result of a single application
@@ -80,7 +83,166 @@ type PinApplicationResult struct {
 	// ApplicationTag is the application for which a leadership pin/unpin
 	// operation was attempted.
 	ApplicationTag string `json:"application-tag"`
+	// EntityTag is the tag of the entity that requested the
+	// pin/unpin operation.
+	EntityTag string `json:"entity-tag,omitempty"`
+	// Info holds information about an operation that succeeded without
+	// needing to do anything, such as pinning an application already
+	// pinned by the same entity, or unpinning one it does not pin.
+	Info string `json:"info,omitempty"`
 	// Error will container a reference to an error resulting from pin/unpin
 	// if one occurred.
 	Error *Error `json:"error,omitempty"`
 }
+
+// ConfirmedPinResults holds the results of pinning leadership
+// for applications and waiting for the pins to take effect.
+type ConfirmedPinResults struct {
+	// Results has an entry for each application pinned.
+	Results []ConfirmedPinResult `json:"results"`
+	// Info holds information about a call that succeeded without operating
+	// on any applications, such as for a machine with no units.
+	Info string `json:"info,omitempty"`
+}
+
+// ConfirmedPinResult holds the result of a single pin operation,
+// along with whether the pin was seen to take effect.
+type ConfirmedPinResult struct {
+	// Result is the result of the pin operation.
+	Result PinApplicationResult `json:"result"`
+	// Confirmed is true if the pin was seen to be in effect
+	// before the wait timed out.
+	Confirmed bool `json:"confirmed"`
+}
+
+// StaggeredUnpinResults holds the results of unpinning leadership
+// for applications, with a stagger between each unpin.
+type StaggeredUnpinResults struct {
+	// Results has an entry for each application unpinned.
+	Results []StaggeredUnpinResult `json:"results"`
+}
+
+// StaggeredUnpinResult holds the result of a single staggered unpin
+// operation, along with the time at which it was performed.
+type StaggeredUnpinResult struct {
+	// Result is the result of the unpin operation.
+	Result PinApplicationResult `json:"result"`
+	// OffsetSeconds holds the number of seconds after the start of the
+	// call at which the unpin was performed.
+	OffsetSeconds float64 `json:"offset-seconds"`
+}
+
+// PinMachinesApplicationsResults holds the results of pinning leadership
+// for applications on multiple machines.
+type PinMachinesApplicationsResults struct {
+	// Results has an entry for each requested machine.
+	Results []PinMachineApplicationsResult `json:"results"`
+}
+
+// PinMachineApplicationsResult holds the results of pinning leadership
+// for applications represented by units on a single machine.
+type PinMachineApplicationsResult struct {
+	// MachineTag is the machine for which pinning was attempted.
+	MachineTag string `json:"machine-tag"`
+	// Results holds the result for each application on the machine.
+	Results []PinApplicationResult `json:"results,omitempty"`
+	// Info holds information about a machine for which pinning succeeded
+	// without operating on any applications, such as one with no units.
+	Info string `json:"info,omitempty"`
+	// Error will contain a reference to an error resulting from
+	// resolving the machine's applications, if one occurred.
+	Error *Error `json:"error,omitempty"`
+}
+
+// PinApplicationLeaderResult holds the result of pinning leadership for an
+// application, along with the unit that was the leader when it was pinned.
+type PinApplicationLeaderResult struct {
+	// Pin is the result of the pin operation.
+	Pin PinApplicationResult `json:"pin"`
+	// LeaderElected is true if a unit held leadership for the application.
+	LeaderElected bool `json:"leader-elected"`
+	// LeaderTag is the tag of the leader unit, if LeaderElected is true.
+	LeaderTag string `json:"leader-tag,omitempty"`
+	// Error will contain a reference to an error resulting from
+	// reading the current leader, if one occurred.
+	Error *Error `json:"error,omitempty"`
+}
+
+// ApplicationNameParams identifies an application by name.
+type ApplicationNameParams struct {
+	// ApplicationName is the name of the application.
+	ApplicationName string `json:"application"`
+}
+
+// PinApplicationParams identifies an application for which leadership
+// is to be pinned, with an optional reason for the pin.
+type PinApplicationParams struct {
+	// Tag is the tag of the application to pin.
+	Tag string `json:"tag"`
+	// Reason, if supplied, is recorded against the pin.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PinnedLeadershipResult holds data about pinned leadership for applications.
+type PinnedLeadershipResult struct {
+	// Result has an entry for each application with pinned leadership,
+	// keyed on application name and containing the tags of all entities
+	// vested in the pinning.
+	Result map[string][]string `json:"result,omitempty"`
+	// Reasons holds any reasons supplied when pinning, keyed on
+	// application name and then on the tag of the pinning entity.
+	Reasons map[string]map[string]string `json:"reasons,omitempty"`
+	// Error will contain a reference to an error resulting from
+	// reading lease data, if one occurred.
+	Error *Error `json:"error,omitempty"`
+}
+
+// ApplicationPinnedResult indicates whether leadership
+// for an application is pinned.
+type ApplicationPinnedResult struct {
+	// Pinned is true if any entity has pinned leadership for the application.
+	Pinned bool `json:"pinned"`
+	// EntityTags holds the tags of all entities pinning the application.
+	EntityTags []string `json:"entity-tags,omitempty"`
+	// Error will contain a reference to an error resulting from
+	// reading lease data, if one occurred.
+	Error *Error `json:"error,omitempty"`
+}
+
+// PinCountsResult holds the number of entities pinning leadership
+// for applications.
+type PinCountsResult struct {
+	// Counts is keyed on application tag, with the number of entities
+	// holding leadership pins for the application.
+	Counts map[string]int `json:"counts"`
+}
+
+// RePinApplicationsParams identifies the entities between which
+// leadership pins are to be transferred.
+type RePinApplicationsParams struct {
+	// SourceTag is the tag of the entity currently holding the pins.
+	SourceTag string `json:"source-tag"`
+	// TargetTag is the tag of the entity to which pins are transferred.
+	TargetTag string `json:"target-tag"`
+}
+
+// UnpinLeadershipStaggerParams holds the duration to wait
+// between successive leadership unpin operations.
+type UnpinLeadershipStaggerParams struct {
+	// StaggerSeconds is the number of seconds between unpins.
+	StaggerSeconds float64 `json:"stagger"`
+}
+
+// PinLeadershipWaitParams holds the duration for which to wait for
+// requested leadership pins to take effect.
+type PinLeadershipWaitParams struct {
+	// TimeoutSeconds is the number of seconds to wait.
+	TimeoutSeconds float64 `json:"timeout"`
+}
+
+// PinLeadershipTTLParams holds the duration for which requested
+// leadership pins should be held before being released automatically.
+type PinLeadershipTTLParams struct {
+	// DurationSeconds is the number of seconds for which pins are held.
+	DurationSeconds float64 `json:"duration"`
+}
@@ -488,6 +488,16 @@ func (ctx *facadeContext) LeadershipPinner(modelUUID string) (leadership.Pinner,
 	return leadershipPinner{pinner}, nil
 }
 
+// LeadershipPinRateLimiter is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipPinRateLimiter() facade.RateLimiter {
+	return ctx.r.shared.leadershipPinRateLimiter
+}
+
+// LeadershipPinMachineCache is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipPinMachineCache() facade.MachineApplicationsCache {
+	return ctx.r.shared.leadershipPinMachineCache
+}
+
 // SingularClaimer is part of the facade.Context interface.
 func (ctx *facadeContext) SingularClaimer() (lease.Claimer, error) {
 	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
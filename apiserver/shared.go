@@ -6,10 +6,12 @@ package apiserver
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/feature"
@@ -40,6 +42,14 @@ type sharedServerContext struct {
 	leaseManager lease.Manager
 	logger       loggo.Logger
 
+	// leadershipPinRateLimiter is shared by all connections, so that
+	// entities are limited regardless of how many connections they make.
+	leadershipPinRateLimiter *common.EntityRateLimiter
+
+	// leadershipPinMachineCache is shared by all connections, so that
+	// concurrent pin requests for a machine reuse its application names.
+	leadershipPinMachineCache *common.MachineApplicationsCache
+
 	featuresMutex sync.RWMutex
 	features      set.Strings
 
@@ -80,6 +90,15 @@ func newSharedServerContex(config sharedServerConfig) (*sharedServerContext, err
 		presence:     config.presence,
 		leaseManager: config.leaseManager,
 		logger:       config.logger,
+		leadershipPinRateLimiter: common.NewEntityRateLimiter(
+			clock.WallClock,
+			common.DefaultLeadershipPinRateLimit.Burst,
+			common.DefaultLeadershipPinRateLimit.Refill,
+		),
+		leadershipPinMachineCache: common.NewMachineApplicationsCache(
+			clock.WallClock,
+			common.MachineApplicationsCacheTTL,
+		),
 	}
 	controllerConfig, err := ctx.statePool.SystemState().ControllerConfig()
 	if err != nil {
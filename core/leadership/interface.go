@@ -52,10 +52,31 @@ type Pinner interface {
 	// pinning operation.
 	PinLeadership(applicationId string, entity names.Tag) error
 
+	// PinLeadershipWithTTL behaves as PinLeadership, but the pin for the
+	// input entity is released automatically after the input duration.
+	PinLeadershipWithTTL(applicationId string, entity names.Tag, ttl time.Duration) error
+
+	// PinLeadershipWithReason behaves as PinLeadership, additionally
+	// recording the input reason against the pin for the input entity.
+	PinLeadershipWithReason(applicationId string, entity names.Tag, reason string) error
+
 	// UnpinLeadership reverses a PinLeadership operation for the same
 	// application and entity. Normal expiry behaviour is restored when no
 	// entities remain with pins for the application.
 	UnpinLeadership(applicationId string, entity names.Tag) error
+
+	// PinnedLeadership returns a map keyed on pinned application names,
+	// with entities that require the application's pinned behaviour.
+	PinnedLeadership() map[string][]names.Tag
+
+	// LeadershipPinExpiries returns the times at which leadership pins made
+	// with a TTL will be released, keyed on application name and pinning
+	// entity. Pins held until unpinned have no entry.
+	LeadershipPinExpiries() map[string]map[names.Tag]time.Time
+
+	// LeadershipPinReasons returns the reasons recorded for leadership pins,
+	// keyed on application name and pinning entity.
+	LeadershipPinReasons() map[string]map[names.Tag]string
 }
 
 // Token represents a unit's leadership of its application.
@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	names_v2 "gopkg.in/juju/names.v2"
 	reflect "reflect"
+	time "time"
 )
 
 // MockPinner is a mock of Pinner interface
@@ -33,6 +34,30 @@ func (m *MockPinner) EXPECT() *MockPinnerMockRecorder {
 	return m.recorder
 }
 
+// LeadershipPinExpiries mocks base method
+func (m *MockPinner) LeadershipPinExpiries() map[string]map[names_v2.Tag]time.Time {
+	ret := m.ctrl.Call(m, "LeadershipPinExpiries")
+	ret0, _ := ret[0].(map[string]map[names_v2.Tag]time.Time)
+	return ret0
+}
+
+// LeadershipPinExpiries indicates an expected call of LeadershipPinExpiries
+func (mr *MockPinnerMockRecorder) LeadershipPinExpiries() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeadershipPinExpiries", reflect.TypeOf((*MockPinner)(nil).LeadershipPinExpiries))
+}
+
+// LeadershipPinReasons mocks base method
+func (m *MockPinner) LeadershipPinReasons() map[string]map[names_v2.Tag]string {
+	ret := m.ctrl.Call(m, "LeadershipPinReasons")
+	ret0, _ := ret[0].(map[string]map[names_v2.Tag]string)
+	return ret0
+}
+
+// LeadershipPinReasons indicates an expected call of LeadershipPinReasons
+func (mr *MockPinnerMockRecorder) LeadershipPinReasons() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeadershipPinReasons", reflect.TypeOf((*MockPinner)(nil).LeadershipPinReasons))
+}
+
 // PinLeadership mocks base method
 func (m *MockPinner) PinLeadership(arg0 string, arg1 names_v2.Tag) error {
 	ret := m.ctrl.Call(m, "PinLeadership", arg0, arg1)
@@ -45,6 +70,42 @@ func (mr *MockPinnerMockRecorder) PinLeadership(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinLeadership", reflect.TypeOf((*MockPinner)(nil).PinLeadership), arg0, arg1)
 }
 
+// PinLeadershipWithReason mocks base method
+func (m *MockPinner) PinLeadershipWithReason(arg0 string, arg1 names_v2.Tag, arg2 string) error {
+	ret := m.ctrl.Call(m, "PinLeadershipWithReason", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinLeadershipWithReason indicates an expected call of PinLeadershipWithReason
+func (mr *MockPinnerMockRecorder) PinLeadershipWithReason(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinLeadershipWithReason", reflect.TypeOf((*MockPinner)(nil).PinLeadershipWithReason), arg0, arg1, arg2)
+}
+
+// PinLeadershipWithTTL mocks base method
+func (m *MockPinner) PinLeadershipWithTTL(arg0 string, arg1 names_v2.Tag, arg2 time.Duration) error {
+	ret := m.ctrl.Call(m, "PinLeadershipWithTTL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinLeadershipWithTTL indicates an expected call of PinLeadershipWithTTL
+func (mr *MockPinnerMockRecorder) PinLeadershipWithTTL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinLeadershipWithTTL", reflect.TypeOf((*MockPinner)(nil).PinLeadershipWithTTL), arg0, arg1, arg2)
+}
+
+// PinnedLeadership mocks base method
+func (m *MockPinner) PinnedLeadership() map[string][]names_v2.Tag {
+	ret := m.ctrl.Call(m, "PinnedLeadership")
+	ret0, _ := ret[0].(map[string][]names_v2.Tag)
+	return ret0
+}
+
+// PinnedLeadership indicates an expected call of PinnedLeadership
+func (mr *MockPinnerMockRecorder) PinnedLeadership() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinnedLeadership", reflect.TypeOf((*MockPinner)(nil).PinnedLeadership))
+}
+
 // UnpinLeadership mocks base method
 func (m *MockPinner) UnpinLeadership(arg0 string, arg1 names_v2.Tag) error {
 	ret := m.ctrl.Call(m, "UnpinLeadership", arg0, arg1)
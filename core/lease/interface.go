@@ -58,10 +58,32 @@ type Pinner interface {
 	// pinning operation.
 	Pin(leaseName string, entity names.Tag) error
 
+	// PinWithTTL behaves as Pin, but the pin held by the input entity is
+	// released automatically once the input duration has elapsed.
+	PinWithTTL(leaseName string, entity names.Tag, ttl time.Duration) error
+
+	// PinWithReason behaves as Pin, additionally recording the input reason
+	// against the pin held by the input entity.
+	PinWithReason(leaseName string, entity names.Tag, reason string) error
+
 	// Unpin reverses a Pin operation for the same application and entity.
 	// Normal expiry behaviour is restored when no entities remain with
 	// pins for the application.
+	// Pins held by other entities are never removed; unpinning a lease
+	// not pinned by the input entity has no effect.
 	Unpin(leaseName string, tag names.Tag) error
+
+	// Pinned returns all names for pinned leases, with the entities requiring
+	// their pinned behaviour.
+	Pinned() map[string][]names.Tag
+
+	// PinExpiries returns the times at which pins made with a TTL will be
+	// released, keyed on lease name and pinning entity.
+	PinExpiries() map[string]map[names.Tag]time.Time
+
+	// PinReasons returns the reasons recorded for pins,
+	// keyed on lease name and pinning entity.
+	PinReasons() map[string]map[names.Tag]string
 }
 
 // Checker exposes facts about lease ownership.
@@ -52,8 +52,18 @@ type Store interface {
 	// the recipient of the pin behaviour.
 	// The input entity denotes the party responsible for the
 	// pinning operation.
+	// A pin made this way is held until unpinned, superseding any
+	// earlier pin by the same entity made with a TTL.
 	PinLease(lease Key, entity names.Tag) error
 
+	// PinLeaseWithTTL is as PinLease, except that the pin is released
+	// automatically once the input duration has elapsed.
+	PinLeaseWithTTL(lease Key, entity names.Tag, ttl time.Duration) error
+
+	// PinLeaseWithReason is as PinLease, additionally recording the
+	// input reason for the pin.
+	PinLeaseWithReason(lease Key, entity names.Tag, reason string) error
+
 	// Unpin reverses a Pin operation for the same key and entity.
 	// Normal expiry behaviour is restored when no entities remain with
 	// pins for the application.
@@ -63,6 +73,15 @@ type Store interface {
 	// The return consists of each pinned lease and the collection of entities
 	// vested in its pinned behaviour.
 	Pinned() map[Key][]names.Tag
+
+	// PinExpiries returns the times at which pins made with a TTL will be
+	// released, keyed on lease and pinning entity.
+	// Pins held until unpinned have no entry.
+	PinExpiries() map[Key]map[names.Tag]time.Time
+
+	// PinReasons returns the reasons recorded for pins,
+	// keyed on lease and pinning entity.
+	PinReasons() map[Key]map[names.Tag]string
 }
 
 // Key fully identifies a lease, including the namespace and
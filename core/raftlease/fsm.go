@@ -22,7 +22,18 @@ const (
 	// CommandVersion is the current version of the command format. If
 	// this changes then we need to be sure that reading and applying
 	// commands for previous versions still works.
-	CommandVersion = 1
+	//
+	// Version 2 allows pin commands to carry a duration and a reason.
+	// Commands that need neither are still written as version 1, so that
+	// controllers that have not yet been upgraded can apply them. Until
+	// all controllers in an HA cluster have been upgraded, those that
+	// have not will reject pins with a duration or reason, so such pins
+	// should not be relied upon during the upgrade.
+	CommandVersion = 2
+
+	// baseCommandVersion is the version written for commands that do not
+	// need any of the features added since the first command format.
+	baseCommandVersion = 1
 
 	// SnapshotVersion is the current version of the snapshot
 	// format. Similarly, changes to the snapshot representation need
@@ -40,7 +51,8 @@ const (
 	OperationSetTime = "setTime"
 
 	// OperationPin pins a lease, preventing it from expiring
-	// until it is unpinned.
+	// until it is unpinned, or until the pin's duration elapses
+	// if one is supplied.
 	OperationPin = "pin"
 
 	// OperationUnpin unpins a lease, restoring normal
@@ -63,8 +75,10 @@ type FSMResponse interface {
 // NewFSM returns a new FSM to store lease information.
 func NewFSM() *FSM {
 	return &FSM{
-		entries: make(map[lease.Key]*entry),
-		pinned:  make(map[lease.Key]set.Tags),
+		entries:     make(map[lease.Key]*entry),
+		pinned:      make(map[lease.Key]set.Tags),
+		pinExpiries: make(map[lease.Key]map[names.Tag]time.Time),
+		pinReasons:  make(map[lease.Key]map[names.Tag]string),
 	}
 }
 
@@ -81,6 +95,15 @@ type FSM struct {
 	// to a lease pinned by another concern operating under under the
 	// assumption that the lease holder will not change.
 	pinned map[lease.Key]set.Tags
+
+	// pinExpiries records the global time at which pins requested with a
+	// duration are released, keyed on lease and pinning entity.
+	// Pins without an entry here are held until explicitly unpinned.
+	pinExpiries map[lease.Key]map[names.Tag]time.Time
+
+	// pinReasons records the reasons supplied for pins,
+	// keyed on lease and pinning entity.
+	pinReasons map[lease.Key]map[names.Tag]string
 }
 
 func (f *FSM) claim(key lease.Key, holder string, duration time.Duration) *response {
@@ -116,11 +139,38 @@ func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) *resp
 	return &response{}
 }
 
-func (f *FSM) pin(key lease.Key, entity names.Tag) *response {
+func (f *FSM) pin(key lease.Key, entity names.Tag, duration time.Duration, reason string) *response {
 	if f.pinned[key] == nil {
 		f.pinned[key] = set.NewTags()
 	}
+	alreadyPinned := f.pinned[key].Contains(entity)
 	f.pinned[key].Add(entity)
+
+	// A pin without a duration supersedes any earlier pin with one.
+	// A pin with a duration never shortens an earlier pin by the same
+	// entity: one without an expiry is left in place, and of two
+	// expiries the later is kept.
+	expiry, hasExpiry := f.pinExpiries[key][entity]
+	newExpiry := f.globalTime.Add(duration)
+	switch {
+	case duration <= 0:
+		f.removePinExpiry(key, entity)
+	case alreadyPinned && !hasExpiry:
+		// Leave the pin without an expiry in place.
+	case !hasExpiry || newExpiry.After(expiry):
+		if f.pinExpiries[key] == nil {
+			f.pinExpiries[key] = make(map[names.Tag]time.Time)
+		}
+		f.pinExpiries[key][entity] = newExpiry
+	}
+
+	// A pin without a reason leaves any earlier reason in place.
+	if reason != "" {
+		if f.pinReasons[key] == nil {
+			f.pinReasons[key] = make(map[names.Tag]string)
+		}
+		f.pinReasons[key][entity] = reason
+	}
 	return &response{}
 }
 
@@ -128,17 +178,42 @@ func (f *FSM) unpin(key lease.Key, entity names.Tag) *response {
 	if f.pinned[key] != nil {
 		f.pinned[key].Remove(entity)
 	}
+	f.removePinExpiry(key, entity)
+	delete(f.pinReasons[key], entity)
+	if len(f.pinReasons[key]) == 0 {
+		delete(f.pinReasons, key)
+	}
 	return &response{}
 }
 
+func (f *FSM) removePinExpiry(key lease.Key, entity names.Tag) {
+	delete(f.pinExpiries[key], entity)
+	if len(f.pinExpiries[key]) == 0 {
+		delete(f.pinExpiries, key)
+	}
+}
+
 func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 	if f.globalTime != oldTime {
 		return &response{err: globalclock.ErrConcurrentUpdate}
 	}
 	f.globalTime = newTime
+	f.removeExpiredPins(newTime)
 	return &response{expired: f.removeExpired(newTime)}
 }
 
+// removeExpiredPins releases pins with a duration that
+// expired before the input time.
+func (f *FSM) removeExpiredPins(newTime time.Time) {
+	for key, entities := range f.pinExpiries {
+		for entity, expiry := range entities {
+			if expiry.Before(newTime) {
+				f.unpin(key, entity)
+			}
+		}
+	}
+}
+
 // expired returns a collection of keys for leases that have expired.
 // Any pinned leases are not included in the return.
 func (f *FSM) removeExpired(newTime time.Time) []lease.Key {
@@ -196,6 +271,37 @@ func (f *FSM) Pinned() map[lease.Key][]names.Tag {
 	return pinned
 }
 
+// PinExpiries returns the expiry times of pins requested with a duration,
+// keyed on lease and pinning entity. Expiry times are expressed relative
+// to the input local time.
+func (f *FSM) PinExpiries(localTime time.Time) map[lease.Key]map[names.Tag]time.Time {
+	f.mu.Lock()
+	expiries := make(map[lease.Key]map[names.Tag]time.Time, len(f.pinExpiries))
+	for key, entities := range f.pinExpiries {
+		expiries[key] = make(map[names.Tag]time.Time, len(entities))
+		for entity, expiry := range entities {
+			expiries[key][entity] = localTime.Add(expiry.Sub(f.globalTime))
+		}
+	}
+	f.mu.Unlock()
+	return expiries
+}
+
+// PinReasons returns the reasons supplied for pins,
+// keyed on lease and pinning entity.
+func (f *FSM) PinReasons() map[lease.Key]map[names.Tag]string {
+	f.mu.Lock()
+	reasons := make(map[lease.Key]map[names.Tag]string, len(f.pinReasons))
+	for key, entities := range f.pinReasons {
+		reasons[key] = make(map[names.Tag]string, len(entities))
+		for entity, reason := range entities {
+			reasons[key][entity] = reason
+		}
+	}
+	f.mu.Unlock()
+	return reasons
+}
+
 func (f *FSM) isPinned(key lease.Key) bool {
 	return !f.pinned[key].IsEmpty()
 }
@@ -266,7 +372,7 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		if err != nil {
 			return &response{err: errors.Trace(err)}
 		}
-		return f.pin(command.LeaseKey(), tag)
+		return f.pin(command.LeaseKey(), tag, command.Duration, command.PinReason)
 	case OperationUnpin:
 		tag, err := names.ParseTag(command.PinEntity)
 		if err != nil {
@@ -313,13 +419,47 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 		}] = entities
 	}
 
+	var pinExpiries map[SnapshotKey]map[string]time.Time
+	for key, entities := range f.pinExpiries {
+		if pinExpiries == nil {
+			pinExpiries = make(map[SnapshotKey]map[string]time.Time)
+		}
+		expiries := make(map[string]time.Time, len(entities))
+		for entity, expiry := range entities {
+			expiries[entity.String()] = expiry
+		}
+		pinExpiries[SnapshotKey{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = expiries
+	}
+
+	var pinReasons map[SnapshotKey]map[string]string
+	for key, entities := range f.pinReasons {
+		if pinReasons == nil {
+			pinReasons = make(map[SnapshotKey]map[string]string)
+		}
+		reasons := make(map[string]string, len(entities))
+		for entity, reason := range entities {
+			reasons[entity.String()] = reason
+		}
+		pinReasons[SnapshotKey{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = reasons
+	}
+
 	f.mu.Unlock()
 
 	return &Snapshot{
-		Version:    SnapshotVersion,
-		Entries:    entries,
-		Pinned:     pinned,
-		GlobalTime: f.globalTime,
+		Version:     SnapshotVersion,
+		Entries:     entries,
+		Pinned:      pinned,
+		PinExpiries: pinExpiries,
+		PinReasons:  pinReasons,
+		GlobalTime:  f.globalTime,
 	}, nil
 }
 
@@ -370,10 +510,48 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 		}] = set.NewTags(tags...)
 	}
 
+	newPinExpiries := make(map[lease.Key]map[names.Tag]time.Time, len(snapshot.PinExpiries))
+	for key, entities := range snapshot.PinExpiries {
+		expiries := make(map[names.Tag]time.Time, len(entities))
+		for e, expiry := range entities {
+			tag, err := names.ParseTag(e)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			expiries[tag] = expiry
+		}
+
+		newPinExpiries[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = expiries
+	}
+
+	newPinReasons := make(map[lease.Key]map[names.Tag]string, len(snapshot.PinReasons))
+	for key, entities := range snapshot.PinReasons {
+		reasons := make(map[names.Tag]string, len(entities))
+		for e, reason := range entities {
+			tag, err := names.ParseTag(e)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			reasons[tag] = reason
+		}
+
+		newPinReasons[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = reasons
+	}
+
 	f.mu.Lock()
 	f.globalTime = snapshot.GlobalTime
 	f.entries = newEntries
 	f.pinned = newPinned
+	f.pinExpiries = newPinExpiries
+	f.pinReasons = newPinReasons
 	f.mu.Unlock()
 
 	return nil
@@ -381,10 +559,12 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 
 // Snapshot defines the format of the FSM snapshot.
 type Snapshot struct {
-	Version    int                           `yaml:"version"`
-	Entries    map[SnapshotKey]SnapshotEntry `yaml:"entries"`
-	Pinned     map[SnapshotKey][]string      `yaml:"pinned"`
-	GlobalTime time.Time                     `yaml:"global-time"`
+	Version     int                                  `yaml:"version"`
+	Entries     map[SnapshotKey]SnapshotEntry        `yaml:"entries"`
+	Pinned      map[SnapshotKey][]string             `yaml:"pinned"`
+	PinExpiries map[SnapshotKey]map[string]time.Time `yaml:"pin-expiries,omitempty"`
+	PinReasons  map[SnapshotKey]map[string]string    `yaml:"pin-reasons,omitempty"`
+	GlobalTime  time.Time                            `yaml:"global-time"`
 }
 
 // Persist is part of raft.FSMSnapshot.
@@ -444,7 +624,8 @@ type Command struct {
 	// lease.
 	Holder string `yaml:"holder,omitempty"`
 
-	// Duration is how long the lease should last.
+	// Duration is how long the lease should last. For a pin, it is
+	// how long the pin should last; zero means until it is unpinned.
 	Duration time.Duration `yaml:"duration,omitempty"`
 
 	// OldTime is the previous time for time updates (to avoid
@@ -457,12 +638,14 @@ type Command struct {
 	// PinEntity is a tag representing an entity concerned
 	// with a pin or unpin operation.
 	PinEntity string `yaml:"pin-entity,omitempty"`
+
+	// PinReason is the reason recorded for a pin operation.
+	PinReason string `yaml:"pin-reason,omitempty"`
 }
 
 // Validate checks that the command describes a valid state change.
 func (c *Command) Validate() error {
-	// For now there's only version 1.
-	if c.Version != 1 {
+	if c.Version < baseCommandVersion || c.Version > CommandVersion {
 		return errors.NotValidf("version %d", c.Version)
 	}
 	switch c.Operation {
@@ -482,6 +665,9 @@ func (c *Command) Validate() error {
 		if c.PinEntity != "" {
 			return errors.NotValidf("%s with pin entity", c.Operation)
 		}
+		if c.PinReason != "" {
+			return errors.NotValidf("%s with pin reason", c.Operation)
+		}
 	case OperationPin, OperationUnpin:
 		if err := c.validateLeaseKey(); err != nil {
 			return err
@@ -489,9 +675,21 @@ func (c *Command) Validate() error {
 		if err := c.validateNoTime(); err != nil {
 			return err
 		}
-		if c.Duration != 0 {
+		if c.Operation == OperationUnpin && c.Duration != 0 {
 			return errors.NotValidf("%s with duration", c.Operation)
 		}
+		if c.Operation == OperationUnpin && c.PinReason != "" {
+			return errors.NotValidf("%s with pin reason", c.Operation)
+		}
+		if c.Duration < 0 {
+			return errors.NotValidf("%s with negative duration", c.Operation)
+		}
+		if c.Version < 2 && c.Duration != 0 {
+			return errors.NotValidf("version %d %s with duration", c.Version, c.Operation)
+		}
+		if c.Version < 2 && c.PinReason != "" {
+			return errors.NotValidf("version %d %s with pin reason", c.Version, c.Operation)
+		}
 		if c.PinEntity == "" {
 			return errors.NotValidf("%s with empty pin entity", c.Operation)
 		}
@@ -522,6 +720,9 @@ func (c *Command) Validate() error {
 		if c.PinEntity != "" {
 			return errors.NotValidf("setTime with pin entity")
 		}
+		if c.PinReason != "" {
+			return errors.NotValidf("setTime with pin reason")
+		}
 	default:
 		return errors.NotValidf("operation %q", c.Operation)
 	}
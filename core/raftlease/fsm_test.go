@@ -364,6 +364,181 @@ func (s *fsmSuite) TestPinUnpinMultipleHoldersNoExpiry(c *gc.C) {
 	assertExpired(c, resp)
 }
 
+func (s *fsmSuite) TestPinWithDurationExpires(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}).Error(), jc.ErrorIsNil)
+
+	machineTag := names.NewMachineTag("0")
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: machineTag.String(),
+		Duration:  3 * time.Second,
+	}).Error(), jc.ErrorIsNil)
+
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	c.Assert(s.fsm.PinExpiries(offset(time.Minute)), gc.DeepEquals, map[lease.Key]map[names.Tag]time.Time{
+		key: {machineTag: offset(time.Minute + 3*time.Second)},
+	})
+
+	// The pin holds the lease beyond its own expiry.
+	resp := s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   offset(2 * time.Second),
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertExpired(c, resp)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]names.Tag{key: {machineTag}})
+
+	// Once the pin's duration elapses, both the pin and the lease go.
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   offset(2 * time.Second),
+		NewTime:   offset(4 * time.Second),
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertExpired(c, resp, key)
+	c.Assert(s.fsm.Pinned(), gc.HasLen, 0)
+	c.Assert(s.fsm.PinExpiries(zero), gc.HasLen, 0)
+}
+
+func (s *fsmSuite) TestPinWithoutDurationSupersedesDuration(c *gc.C) {
+	machineTag := names.NewMachineTag("0")
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: machineTag.String(),
+		Duration:  time.Second,
+	}
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinExpiries(zero), gc.HasLen, 1)
+
+	command.Duration = 0
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinExpiries(zero), gc.HasLen, 0)
+
+	// The pin now survives the original duration.
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   offset(2 * time.Second),
+	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]names.Tag{
+		{Namespace: "ns", ModelUUID: "model", Lease: "lease"}: {machineTag},
+	})
+}
+
+func (s *fsmSuite) TestPinWithDurationDoesNotShortenPin(c *gc.C) {
+	machineTag := names.NewMachineTag("0")
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: machineTag.String(),
+	}
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+
+	// A pin with a duration leaves the earlier pin without one in place.
+	command.Duration = time.Second
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinExpiries(zero), gc.HasLen, 0)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   offset(2 * time.Second),
+	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]names.Tag{key: {machineTag}})
+}
+
+func (s *fsmSuite) TestPinWithDurationKeepsLaterExpiry(c *gc.C) {
+	machineTag := names.NewMachineTag("0")
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: machineTag.String(),
+		Duration:  time.Minute,
+	}
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+
+	command.Duration = time.Second
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinExpiries(zero), gc.DeepEquals, map[lease.Key]map[names.Tag]time.Time{
+		key: {machineTag: offset(time.Minute)},
+	})
+
+	command.Duration = 2 * time.Minute
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinExpiries(zero), gc.DeepEquals, map[lease.Key]map[names.Tag]time.Time{
+		key: {machineTag: offset(2 * time.Minute)},
+	})
+}
+
+func (s *fsmSuite) TestPinReasons(c *gc.C) {
+	m0Tag := names.NewMachineTag("0")
+	m1Tag := names.NewMachineTag("1")
+	command := raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: m0Tag.String(),
+		PinReason: "series upgrade",
+	}
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+
+	// A pin without a reason leaves the earlier reason in place.
+	command.PinReason = ""
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+	command.PinEntity = m1Tag.String()
+	command.PinReason = "maintenance"
+	c.Assert(s.apply(c, command).Error(), jc.ErrorIsNil)
+
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	c.Assert(s.fsm.PinReasons(), gc.DeepEquals, map[lease.Key]map[names.Tag]string{
+		key: {m0Tag: "series upgrade", m1Tag: "maintenance"},
+	})
+
+	// Unpinning removes the entity's reason.
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationUnpin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: m0Tag.String(),
+	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.PinReasons(), gc.DeepEquals, map[lease.Key]map[names.Tag]string{
+		key: {m1Tag: "maintenance"},
+	})
+}
+
 func (s *fsmSuite) TestLeases(c *gc.C) {
 	c.Assert(s.apply(c, raftlease.Command{
 		Version:   1,
@@ -532,6 +707,16 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 		Lease:     "lease",
 		PinEntity: machineTag.String(),
 	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   2,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns2",
+		ModelUUID: "model2",
+		Lease:     "lease",
+		PinEntity: machineTag.String(),
+		Duration:  time.Minute,
+		PinReason: "series upgrade",
+	}).Error(), jc.ErrorIsNil)
 
 	snapshot, err := s.fsm.Snapshot()
 	c.Assert(err, jc.ErrorIsNil)
@@ -551,7 +736,14 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 		},
 		GlobalTime: zero.Add(2 * time.Second),
 		Pinned: map[raftlease.SnapshotKey][]string{
-			{"ns", "model", "lease"}: {machineTag.String()},
+			{"ns", "model", "lease"}:   {machineTag.String()},
+			{"ns2", "model2", "lease"}: {machineTag.String()},
+		},
+		PinExpiries: map[raftlease.SnapshotKey]map[string]time.Time{
+			{"ns2", "model2", "lease"}: {machineTag.String(): zero.Add(time.Minute + 2*time.Second)},
+		},
+		PinReasons: map[raftlease.SnapshotKey]map[string]string{
+			{"ns2", "model2", "lease"}: {machineTag.String(): "series upgrade"},
 		},
 	})
 }
@@ -613,7 +805,14 @@ func (s *fsmSuite) TestSnapshotPersist(c *gc.C) {
 			},
 		},
 		Pinned: map[raftlease.SnapshotKey][]string{
-			{"ns", "model", "lease"}: {names.NewMachineTag("0").String()},
+			{"ns", "model", "lease"}:   {names.NewMachineTag("0").String()},
+			{"ns2", "model2", "lease"}: {names.NewMachineTag("0").String()},
+		},
+		PinExpiries: map[raftlease.SnapshotKey]map[string]time.Time{
+			{"ns2", "model2", "lease"}: {names.NewMachineTag("0").String(): zero.Add(time.Minute)},
+		},
+		PinReasons: map[raftlease.SnapshotKey]map[string]string{
+			{"ns2", "model2", "lease"}: {names.NewMachineTag("0").String(): "series upgrade"},
 		},
 		GlobalTime: zero.Add(2 * time.Second),
 	}
@@ -699,13 +898,55 @@ func (s *fsmSuite) TestCommandValidationPin(c *gc.C) {
 	command.Namespace = ""
 	c.Assert(command.Validate(), gc.ErrorMatches, "pin with empty namespace not valid")
 	command.Namespace = "namespace"
-	command.Duration = time.Minute
-	c.Assert(command.Validate(), gc.ErrorMatches, "pin with duration not valid")
+	command.Duration = -time.Minute
+	c.Assert(command.Validate(), gc.ErrorMatches, "pin with negative duration not valid")
 	command.Duration = 0
 	command.PinEntity = ""
 	c.Assert(command.Validate(), gc.ErrorMatches, "pin with empty pin entity not valid")
 }
 
+func (s *fsmSuite) TestCommandValidationPinVersions(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: names.NewMachineTag("0").String(),
+		Duration:  time.Minute,
+	}
+	// Durations and reasons were added to pins in version 2.
+	c.Assert(command.Validate(), gc.ErrorMatches, "version 1 pin with duration not valid")
+	command.Duration = 0
+	command.PinReason = "series upgrade"
+	c.Assert(command.Validate(), gc.ErrorMatches, "version 1 pin with pin reason not valid")
+
+	command.Version = 2
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Duration = time.Minute
+	c.Assert(command.Validate(), gc.Equals, nil)
+
+	command.Version = 3
+	c.Assert(command.Validate(), gc.ErrorMatches, "version 3 not valid")
+}
+
+func (s *fsmSuite) TestCommandValidationUnpin(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationUnpin,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: names.NewMachineTag("0").String(),
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Duration = time.Minute
+	c.Assert(command.Validate(), gc.ErrorMatches, "unpin with duration not valid")
+	command.Duration = 0
+	command.PinReason = "series upgrade"
+	c.Assert(command.Validate(), gc.ErrorMatches, "unpin with pin reason not valid")
+}
+
 func assertClaimed(c *gc.C, resp raftlease.FSMResponse, key lease.Key, holder string) {
 	var target fakeTarget
 	resp.Notify(&target)
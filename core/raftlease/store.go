@@ -44,6 +44,8 @@ type ReadonlyFSM interface {
 	Leases(time.Time) map[lease.Key]lease.Info
 	GlobalTime() time.Time
 	Pinned() map[lease.Key][]names.Tag
+	PinExpiries(time.Time) map[lease.Key]map[names.Tag]time.Time
+	PinReasons() map[lease.Key]map[names.Tag]string
 }
 
 // StoreConfig holds resources and settings needed to run the Store.
@@ -82,7 +84,7 @@ type Store struct {
 // ClaimLease is part of lease.Store.
 func (s *Store) ClaimLease(key lease.Key, req lease.Request) error {
 	err := s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   baseCommandVersion,
 		Operation: OperationClaim,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
@@ -96,7 +98,7 @@ func (s *Store) ClaimLease(key lease.Key, req lease.Request) error {
 // ExtendLease is part of lease.Store.
 func (s *Store) ExtendLease(key lease.Key, req lease.Request) error {
 	return errors.Trace(s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   baseCommandVersion,
 		Operation: OperationExtend,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
@@ -132,12 +134,28 @@ func (s *Store) Refresh() error {
 
 // PinLease is part of lease.Store.
 func (s *Store) PinLease(key lease.Key, entity names.Tag) error {
-	return errors.Trace(s.pinOp(OperationPin, key, entity))
+	return errors.Trace(s.runOnLeader(s.pinCommand(OperationPin, key, entity)))
+}
+
+// PinLeaseWithTTL is part of lease.Store.
+func (s *Store) PinLeaseWithTTL(key lease.Key, entity names.Tag, ttl time.Duration) error {
+	command := s.pinCommand(OperationPin, key, entity)
+	command.Version = CommandVersion
+	command.Duration = ttl
+	return errors.Trace(s.runOnLeader(command))
+}
+
+// PinLeaseWithReason is part of lease.Store.
+func (s *Store) PinLeaseWithReason(key lease.Key, entity names.Tag, reason string) error {
+	command := s.pinCommand(OperationPin, key, entity)
+	command.Version = CommandVersion
+	command.PinReason = reason
+	return errors.Trace(s.runOnLeader(command))
 }
 
 // UnpinLease is part of lease.Store.
 func (s *Store) UnpinLease(key lease.Key, entity names.Tag) error {
-	return errors.Trace(s.pinOp(OperationUnpin, key, entity))
+	return errors.Trace(s.runOnLeader(s.pinCommand(OperationUnpin, key, entity)))
 }
 
 // Pinned is part of the Store interface.
@@ -145,15 +163,25 @@ func (s *Store) Pinned() map[lease.Key][]names.Tag {
 	return s.fsm.Pinned()
 }
 
-func (s *Store) pinOp(operation string, key lease.Key, entity names.Tag) error {
-	return errors.Trace(s.runOnLeader(&Command{
-		Version:   CommandVersion,
+// PinExpiries is part of the Store interface.
+func (s *Store) PinExpiries() map[lease.Key]map[names.Tag]time.Time {
+	return s.fsm.PinExpiries(s.config.Clock.Now())
+}
+
+// PinReasons is part of the Store interface.
+func (s *Store) PinReasons() map[lease.Key]map[names.Tag]string {
+	return s.fsm.PinReasons()
+}
+
+func (s *Store) pinCommand(operation string, key lease.Key, entity names.Tag) *Command {
+	return &Command{
+		Version:   baseCommandVersion,
 		Operation: operation,
 		Namespace: key.Namespace,
 		ModelUUID: key.ModelUUID,
 		Lease:     key.Lease,
 		PinEntity: entity.String(),
-	}))
+	}
 }
 
 // Advance is part of globalclock.Updater.
@@ -162,7 +190,7 @@ func (s *Store) Advance(duration time.Duration) error {
 	defer s.prevTimeMu.Unlock()
 	newTime := s.prevTime.Add(duration)
 	err := s.runOnLeader(&Command{
-		Version:   CommandVersion,
+		Version:   baseCommandVersion,
 		Operation: OperationSetTime,
 		OldTime:   s.prevTime,
 		NewTime:   newTime,
@@ -253,6 +253,66 @@ func (s *storeSuite) TestPin(c *gc.C) {
 	)
 }
 
+func (s *storeSuite) TestPinWithTTL(c *gc.C) {
+	machineTag := names.NewMachineTag("0")
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.PinLeaseWithTTL(
+				lease.Key{"warframe", "frost", "prime"},
+				machineTag,
+				time.Minute,
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationPin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: machineTag.String(),
+			Duration:  time.Minute,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestPinWithReason(c *gc.C) {
+	machineTag := names.NewMachineTag("0")
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.PinLeaseWithReason(
+				lease.Key{"warframe", "frost", "prime"},
+				machineTag,
+				"series upgrade",
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   2,
+			Operation: raftlease.OperationPin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: machineTag.String(),
+			PinReason: "series upgrade",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
 func (s *storeSuite) TestUnpin(c *gc.C) {
 	machineTag := names.NewMachineTag("0")
 	s.handleHubRequest(c,
@@ -287,6 +347,20 @@ func (s *storeSuite) TestPinned(c *gc.C) {
 	s.fsm.CheckCallNames(c, "Pinned")
 }
 
+func (s *storeSuite) TestPinExpiries(c *gc.C) {
+	s.fsm.pinExpiries = map[lease.Key]map[names.Tag]time.Time{}
+	c.Check(s.store.PinExpiries(), gc.DeepEquals, s.fsm.pinExpiries)
+	s.fsm.CheckCalls(c, []testing.StubCall{
+		{"PinExpiries", []interface{}{s.clock.Now()}},
+	})
+}
+
+func (s *storeSuite) TestPinReasons(c *gc.C) {
+	s.fsm.pinReasons = map[lease.Key]map[names.Tag]string{}
+	c.Check(s.store.PinReasons(), gc.DeepEquals, s.fsm.pinReasons)
+	s.fsm.CheckCallNames(c, "PinReasons")
+}
+
 // handleHubRequest takes the action that triggers the request, the
 // expected command, and a function that will be run to make checks on
 // the request and send the response back.
@@ -460,9 +534,11 @@ func (s *storeSuite) TestRecoverError(c *gc.C) {
 
 type fakeFSM struct {
 	testing.Stub
-	leases     map[lease.Key]lease.Info
-	globalTime time.Time
-	pinned     map[lease.Key][]names.Tag
+	leases      map[lease.Key]lease.Info
+	globalTime  time.Time
+	pinned      map[lease.Key][]names.Tag
+	pinExpiries map[lease.Key]map[names.Tag]time.Time
+	pinReasons  map[lease.Key]map[names.Tag]string
 }
 
 func (f *fakeFSM) Leases(t time.Time) map[lease.Key]lease.Info {
@@ -475,6 +551,16 @@ func (f *fakeFSM) Pinned() map[lease.Key][]names.Tag {
 	return f.pinned
 }
 
+func (f *fakeFSM) PinExpiries(t time.Time) map[lease.Key]map[names.Tag]time.Time {
+	f.AddCall("PinExpiries", t)
+	return f.pinExpiries
+}
+
+func (f *fakeFSM) PinReasons() map[lease.Key]map[names.Tag]string {
+	f.AddCall("PinReasons")
+	return f.pinReasons
+}
+
 func (f *fakeFSM) GlobalTime() time.Time {
 	return f.globalTime
 }
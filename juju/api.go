@@ -16,7 +16,15 @@ import (
 	"github.com/juju/juju/network"
 )
 
-var logger = loggo.GetLogger("juju.juju")
+// LoggerName is the name of the logger used by this package.
+const LoggerName = "juju.juju"
+
+var logger = loggo.GetLogger(LoggerName)
+
+// SetLogLevel sets the level of the logger used by this package.
+func SetLogLevel(level loggo.Level) {
+	logger.SetLogLevel(level)
+}
 
 // NewAPIConnectionParams contains the parameters for creating a new Juju API
 // connection.
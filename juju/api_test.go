@@ -10,6 +10,7 @@ import (
 	"net"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -562,3 +563,21 @@ type ipAddrResolverFunc func(ctx context.Context, host string) ([]net.IPAddr, er
 func (f ipAddrResolverFunc) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
 	return f(ctx, host)
 }
+
+type loggerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&loggerSuite{})
+
+func (s *loggerSuite) TestSetLogLevel(c *gc.C) {
+	logger := loggo.GetLogger(juju.LoggerName)
+	level := logger.LogLevel()
+	s.AddCleanup(func(*gc.C) { logger.SetLogLevel(level) })
+
+	juju.SetLogLevel(loggo.TRACE)
+	c.Check(logger.LogLevel(), gc.Equals, loggo.TRACE)
+
+	juju.SetLogLevel(loggo.ERROR)
+	c.Check(logger.LogLevel(), gc.Equals, loggo.ERROR)
+}
@@ -130,6 +130,16 @@ func (s *leaseStore) PinLease(key lease.Key, entity names.Tag) error {
 	return errors.NotImplementedf("lease pinning")
 }
 
+// PinLeaseWithTTL is part of lease.Store.
+func (s *leaseStore) PinLeaseWithTTL(key lease.Key, entity names.Tag, ttl time.Duration) error {
+	return errors.NotImplementedf("lease pinning")
+}
+
+// PinLeaseWithReason is part of lease.Store.
+func (s *leaseStore) PinLeaseWithReason(key lease.Key, entity names.Tag, reason string) error {
+	return errors.NotImplementedf("lease pinning")
+}
+
 // UnpinLease is part of lease.Store.
 func (s *leaseStore) UnpinLease(key lease.Key, entity names.Tag) error {
 	return errors.NotImplementedf("lease unpinning")
@@ -139,3 +149,13 @@ func (s *leaseStore) UnpinLease(key lease.Key, entity names.Tag) error {
 func (s *leaseStore) Pinned() map[lease.Key][]names.Tag {
 	return nil
 }
+
+// PinExpiries is part of the Store interface.
+func (s *leaseStore) PinExpiries() map[lease.Key]map[names.Tag]time.Time {
+	return nil
+}
+
+// PinReasons is part of the Store interface.
+func (s *leaseStore) PinReasons() map[lease.Key]map[names.Tag]string {
+	return nil
+}
@@ -194,6 +194,16 @@ func (store *store) PinLease(key lease.Key, entity names.Tag) error {
 	return errors.NotImplementedf("pinning for legacy leases")
 }
 
+// PinLeaseWithTTL is part of the Store interface.
+func (store *store) PinLeaseWithTTL(key lease.Key, entity names.Tag, ttl time.Duration) error {
+	return errors.NotImplementedf("pinning for legacy leases")
+}
+
+// PinLeaseWithReason is part of the Store interface.
+func (store *store) PinLeaseWithReason(key lease.Key, entity names.Tag, reason string) error {
+	return errors.NotImplementedf("pinning for legacy leases")
+}
+
 // UnpinLease is part of the Store interface.
 func (store *store) UnpinLease(key lease.Key, entity names.Tag) error {
 	return errors.NotImplementedf("unpinning for legacy leases")
@@ -204,6 +214,16 @@ func (store *store) Pinned() map[lease.Key][]names.Tag {
 	return nil
 }
 
+// PinExpiries is part of the Store interface.
+func (store *store) PinExpiries() map[lease.Key]map[names.Tag]time.Time {
+	return nil
+}
+
+// PinReasons is part of the Store interface.
+func (store *store) PinReasons() map[lease.Key]map[names.Tag]string {
+	return nil
+}
+
 // Refresh is part of the Store interface.
 func (store *store) Refresh() error {
 	store.mu.Lock()
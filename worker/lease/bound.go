@@ -78,23 +78,62 @@ func (b *boundManager) Token(leaseName, holderName string) lease.Token {
 
 // Pin (lease.Pinner) sends a pin message to the worker loop.
 func (b *boundManager) Pin(leaseName string, entity names.Tag) error {
-	return errors.Trace(b.pinOp(leaseName, entity, b.manager.pins))
+	return errors.Trace(b.pinOp(b.pin(leaseName, entity), b.manager.pins))
+}
+
+// PinWithTTL (lease.Pinner) sends a pin message to the worker loop,
+// requesting that the pin be released after the input duration.
+func (b *boundManager) PinWithTTL(leaseName string, entity names.Tag, ttl time.Duration) error {
+	p := b.pin(leaseName, entity)
+	p.ttl = ttl
+	return errors.Trace(b.pinOp(p, b.manager.pins))
+}
+
+// PinWithReason (lease.Pinner) sends a pin message to the worker loop,
+// with a reason to be recorded against the pin.
+func (b *boundManager) PinWithReason(leaseName string, entity names.Tag, reason string) error {
+	p := b.pin(leaseName, entity)
+	p.reason = reason
+	return errors.Trace(b.pinOp(p, b.manager.pins))
 }
 
 // Unpin (lease.Pinner) sends an unpin message to the worker loop.
 func (b *boundManager) Unpin(leaseName string, entity names.Tag) error {
-	return errors.Trace(b.pinOp(leaseName, entity, b.manager.unpins))
+	return errors.Trace(b.pinOp(b.pin(leaseName, entity), b.manager.unpins))
+}
+
+// Pinned (lease.Pinner) returns lease names and the entities requiring their
+// pinned behaviour, for pinned leases in the bound namespace and model.
+func (b *boundManager) Pinned() map[string][]names.Tag {
+	return b.manager.pinned(b.namespace, b.modelUUID)
+}
+
+// PinExpiries (lease.Pinner) returns the times at which pins made with a TTL
+// will be released, for pinned leases in the bound namespace and model.
+func (b *boundManager) PinExpiries() map[string]map[names.Tag]time.Time {
+	return b.manager.pinExpiries(b.namespace, b.modelUUID)
+}
+
+// PinReasons (lease.Pinner) returns the reasons recorded for pins in the
+// bound namespace and model, keyed on lease name and pinning entity.
+func (b *boundManager) PinReasons() map[string]map[names.Tag]string {
+	return b.manager.reasons(b.namespace, b.modelUUID)
 }
 
-// pinOp creates a pin instance from the input lease name,
-// then sends it on the input channel.
-func (b *boundManager) pinOp(leaseName string, entity names.Tag, ch chan pin) error {
-	return errors.Trace(pin{
+// pin returns a pin instance for the input lease name and entity.
+func (b *boundManager) pin(leaseName string, entity names.Tag) pin {
+	return pin{
 		leaseKey: b.leaseKey(leaseName),
 		entity:   entity,
-		response: make(chan error),
-		stop:     b.manager.catacomb.Dying(),
-	}.invoke(ch))
+	}
+}
+
+// pinOp sends the input pin instance on the input channel,
+// and waits for the response.
+func (b *boundManager) pinOp(p pin, ch chan pin) error {
+	p.response = make(chan error)
+	p.stop = b.manager.catacomb.Dying()
+	return errors.Trace(p.invoke(ch))
 }
 
 // leaseKey returns a key for the manager's binding and the input lease name.
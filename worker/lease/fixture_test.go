@@ -10,6 +10,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	corelease "github.com/juju/juju/core/lease"
 	coretesting "github.com/juju/juju/testing"
@@ -70,6 +71,18 @@ type Fixture struct {
 	// test starts up.
 	leases map[corelease.Key]corelease.Info
 
+	// pinned contains the pinned leases and entities that the
+	// corelease.Store should report.
+	pinned map[corelease.Key][]names.Tag
+
+	// pinExpiries contains the expiry times of pins made with a TTL
+	// that the corelease.Store should report.
+	pinExpiries map[corelease.Key]map[names.Tag]time.Time
+
+	// pinReasons contains the reasons recorded for pins
+	// that the corelease.Store should report.
+	pinReasons map[corelease.Key]map[names.Tag]string
+
 	// expectCalls contains the calls that should be made to the corelease.Store
 	// in the course of a test. By specifying a callback you can cause the
 	// reported leases to change.
@@ -86,6 +99,9 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testclock.Clock)) {
 	clock := testclock.NewClock(defaultClockStart)
 	store := NewStore(fix.leases, fix.expectCalls)
+	store.pinned = fix.pinned
+	store.pinExpiries = fix.pinExpiries
+	store.pinReasons = fix.pinReasons
 	manager, err := lease.NewManager(lease.ManagerConfig{
 		Clock: clock,
 		Store: store,
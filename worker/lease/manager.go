@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1/catacomb"
 	"gopkg.in/retry.v1"
 
@@ -449,13 +450,58 @@ func (manager *Manager) startRetry() *retry.Attempt {
 }
 
 func (manager *Manager) handlePin(p pin) {
-	p.respond(errors.Trace(manager.config.Store.PinLease(p.leaseKey, p.entity)))
+	var err error
+	switch {
+	case p.ttl > 0:
+		err = manager.config.Store.PinLeaseWithTTL(p.leaseKey, p.entity, p.ttl)
+	case p.reason != "":
+		err = manager.config.Store.PinLeaseWithReason(p.leaseKey, p.entity, p.reason)
+	default:
+		err = manager.config.Store.PinLease(p.leaseKey, p.entity)
+	}
+	p.respond(errors.Trace(err))
 }
 
 func (manager *Manager) handleUnpin(p pin) {
 	p.respond(errors.Trace(manager.config.Store.UnpinLease(p.leaseKey, p.entity)))
 }
 
+// pinned returns lease names and the entities requiring their pinned
+// behaviour, for pinned leases in the input namespace and model.
+func (manager *Manager) pinned(namespace, modelUUID string) map[string][]names.Tag {
+	pinned := make(map[string][]names.Tag)
+	for key, entities := range manager.config.Store.Pinned() {
+		if key.Namespace == namespace && key.ModelUUID == modelUUID {
+			pinned[key.Lease] = entities
+		}
+	}
+	return pinned
+}
+
+// pinExpiries returns the times at which pins made with a TTL will be
+// released, for pinned leases in the input namespace and model.
+func (manager *Manager) pinExpiries(namespace, modelUUID string) map[string]map[names.Tag]time.Time {
+	expiries := make(map[string]map[names.Tag]time.Time)
+	for key, entities := range manager.config.Store.PinExpiries() {
+		if key.Namespace == namespace && key.ModelUUID == modelUUID {
+			expiries[key.Lease] = entities
+		}
+	}
+	return expiries
+}
+
+// reasons returns the reasons recorded for pins in the input namespace and
+// model, keyed on lease name and pinning entity.
+func (manager *Manager) reasons(namespace, modelUUID string) map[string]map[names.Tag]string {
+	reasons := make(map[string]map[names.Tag]string)
+	for key, entities := range manager.config.Store.PinReasons() {
+		if key.Namespace == namespace && key.ModelUUID == modelUUID {
+			reasons[key.Lease] = entities
+		}
+	}
+	return reasons
+}
+
 func keysLess(a, b lease.Key) bool {
 	if a.Namespace == b.Namespace && a.ModelUUID == b.ModelUUID {
 		return a.Lease < b.Lease
@@ -4,6 +4,8 @@
 package lease_test
 
 import (
+	"time"
+
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -20,6 +22,7 @@ type PinSuite struct {
 
 	appName    string
 	machineTag names.MachineTag
+	leaseKey   corelease.Key
 	pinArgs    []interface{}
 }
 
@@ -28,14 +31,12 @@ func (s *PinSuite) SetUpTest(c *gc.C) {
 
 	s.appName = "redis"
 	s.machineTag = names.NewMachineTag("0")
-	s.pinArgs = []interface{}{
-		corelease.Key{
-			Namespace: "namespace",
-			ModelUUID: "modelUUID",
-			Lease:     s.appName,
-		},
-		s.machineTag,
+	s.leaseKey = corelease.Key{
+		Namespace: "namespace",
+		ModelUUID: "modelUUID",
+		Lease:     s.appName,
 	}
+	s.pinArgs = []interface{}{s.leaseKey, s.machineTag}
 }
 
 var _ = gc.Suite(&PinSuite{})
@@ -94,6 +95,98 @@ func (s *PinSuite) TestUnpinLease_Error(c *gc.C) {
 	})
 }
 
+func (s *PinSuite) TestPinLeaseWithTTL(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLeaseWithTTL",
+			args:   append(s.pinArgs, time.Minute),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).PinWithTTL(s.appName, s.machineTag, time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinLeaseWithTTL_UnpinnedBeforeExpiry(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLeaseWithTTL",
+			args:   append(s.pinArgs, time.Minute),
+		}, {
+			method: "UnpinLease",
+			args:   s.pinArgs,
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		pinner := getPinner(c, manager)
+		err := pinner.PinWithTTL(s.appName, s.machineTag, time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+		err = pinner.Unpin(s.appName, s.machineTag)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinLeaseWithReason(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLeaseWithReason",
+			args:   append(s.pinArgs, "maintenance"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).PinWithReason(s.appName, s.machineTag, "maintenance")
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinReasons(c *gc.C) {
+	fix := &Fixture{
+		pinReasons: map[corelease.Key]map[names.Tag]string{
+			{Namespace: "namespace", ModelUUID: "modelUUID", Lease: s.appName}: {s.machineTag: "maintenance"},
+			{Namespace: "namespace", ModelUUID: "otherUUID", Lease: "mysql"}:   {s.machineTag: "maintenance"},
+			{Namespace: "othername", ModelUUID: "modelUUID", Lease: "mysql"}:   {s.machineTag: "maintenance"},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		reasons := getPinner(c, manager).PinReasons()
+		c.Check(reasons, gc.DeepEquals, map[string]map[names.Tag]string{
+			s.appName: {s.machineTag: "maintenance"},
+		})
+	})
+}
+
+func (s *PinSuite) TestPinned(c *gc.C) {
+	fix := &Fixture{
+		pinned: map[corelease.Key][]names.Tag{
+			{Namespace: "namespace", ModelUUID: "modelUUID", Lease: s.appName}: {s.machineTag},
+			{Namespace: "namespace", ModelUUID: "otherUUID", Lease: "mysql"}:   {s.machineTag},
+			{Namespace: "othername", ModelUUID: "modelUUID", Lease: "mysql"}:   {s.machineTag},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		pinned := getPinner(c, manager).Pinned()
+		c.Check(pinned, gc.DeepEquals, map[string][]names.Tag{s.appName: {s.machineTag}})
+	})
+}
+
+func (s *PinSuite) TestPinExpiries(c *gc.C) {
+	expiry := defaultClockStart.Add(time.Minute)
+	fix := &Fixture{
+		pinExpiries: map[corelease.Key]map[names.Tag]time.Time{
+			{Namespace: "namespace", ModelUUID: "modelUUID", Lease: s.appName}: {s.machineTag: expiry},
+			{Namespace: "namespace", ModelUUID: "otherUUID", Lease: "mysql"}:   {s.machineTag: expiry},
+			{Namespace: "othername", ModelUUID: "modelUUID", Lease: "mysql"}:   {s.machineTag: expiry},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		expiries := getPinner(c, manager).PinExpiries()
+		c.Check(expiries, gc.DeepEquals, map[string]map[names.Tag]time.Time{
+			s.appName: {s.machineTag: expiry},
+		})
+	})
+}
+
 func getPinner(c *gc.C, manager *lease.Manager) corelease.Pinner {
 	pinner, err := manager.Pinner("namespace", "modelUUID")
 	c.Assert(err, jc.ErrorIsNil)
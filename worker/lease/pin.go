@@ -4,6 +4,8 @@
 package lease
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/lease"
@@ -14,6 +16,11 @@ import (
 type pin struct {
 	leaseKey lease.Key
 	entity   names.Tag
+	// ttl, if positive, is the duration after which the pin
+	// is automatically released.
+	ttl time.Duration
+	// reason, if not empty, is recorded against the pin.
+	reason   string
 	response chan error
 	stop     <-chan struct{}
 }
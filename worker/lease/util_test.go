@@ -48,6 +48,9 @@ func (Secretary) CheckDuration(duration time.Duration) error {
 type Store struct {
 	mu           sync.Mutex
 	leases       map[lease.Key]lease.Info
+	pinned       map[lease.Key][]names.Tag
+	pinExpiries  map[lease.Key]map[names.Tag]time.Time
+	pinReasons   map[lease.Key]map[names.Tag]string
 	expect       []call
 	failed       chan error
 	runningCalls int
@@ -177,13 +180,52 @@ func (store *Store) PinLease(key lease.Key, entity names.Tag) error {
 	return store.call("PinLease", []interface{}{key, entity})
 }
 
+// PinLeaseWithTTL is part of the corelease.Store interface.
+func (store *Store) PinLeaseWithTTL(key lease.Key, entity names.Tag, ttl time.Duration) error {
+	return store.call("PinLeaseWithTTL", []interface{}{key, entity, ttl})
+}
+
+// PinLeaseWithReason is part of the corelease.Store interface.
+func (store *Store) PinLeaseWithReason(key lease.Key, entity names.Tag, reason string) error {
+	return store.call("PinLeaseWithReason", []interface{}{key, entity, reason})
+}
+
 // UnpinLease is part of the corelease.Store interface.
 func (store *Store) UnpinLease(key lease.Key, entity names.Tag) error {
 	return store.call("UnpinLease", []interface{}{key, entity})
 }
 
-func (Store *Store) Pinned() map[lease.Key][]names.Tag {
-	return nil
+// Pinned is part of the corelease.Store interface.
+func (store *Store) Pinned() map[lease.Key][]names.Tag {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key][]names.Tag)
+	for k, v := range store.pinned {
+		result[k] = v
+	}
+	return result
+}
+
+// PinExpiries is part of the corelease.Store interface.
+func (store *Store) PinExpiries() map[lease.Key]map[names.Tag]time.Time {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key]map[names.Tag]time.Time)
+	for k, v := range store.pinExpiries {
+		result[k] = v
+	}
+	return result
+}
+
+// PinReasons is part of the corelease.Store interface.
+func (store *Store) PinReasons() map[lease.Key]map[names.Tag]string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key]map[names.Tag]string)
+	for k, v := range store.pinReasons {
+		result[k] = v
+	}
+	return result
 }
 
 // call defines a expected method call on a Store; it encodes: